@@ -0,0 +1,58 @@
+package avwx
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// altimeterToleranceHpa is how far apart, in hectopascals, the A and Q groups of one report may be
+// before they are taken to disagree. It allows for each being rounded to its own unit.
+const altimeterToleranceHpa = 1.0
+
+// AltimeterDec is an altimeter setting as reported in a single A or Q group, such as "A2992" or "Q1013",
+// given in both units.
+type AltimeterDec struct {
+	Group string
+	InHg  float64
+	Hpa   float64
+}
+
+// decodeAltimeters decodes each altimeter group in a report's body. Reports from joint civil and military
+// fields may carry both an A and a Q group.
+func decodeAltimeters(groups []string) []AltimeterDec {
+	var altimeters []AltimeterDec
+	for _, group := range groups {
+		if trendTypes[group] {
+			break
+		}
+		if !altimeterPattern.MatchString(group) {
+			continue
+		}
+		value, _ := strconv.ParseFloat(group[1:], 64)
+		altimeter := AltimeterDec{Group: group}
+		if group[0] == 'Q' {
+			altimeter.Hpa = value
+			altimeter.InHg = value / hPaPerInHg
+		} else {
+			altimeter.InHg = value / 100
+			altimeter.Hpa = value / 100 * hPaPerInHg
+		}
+		altimeters = append(altimeters, altimeter)
+	}
+	return altimeters
+}
+
+// reconcileAltimeters records a decode error when the altimeter groups of a report disagree.
+func (m *Metar) reconcileAltimeters() {
+	for i := 1; i < len(m.Altimeters); i++ {
+		if math.Abs(m.Altimeters[i].Hpa-m.Altimeters[0].Hpa) > altimeterToleranceHpa {
+			groups := make([]string, len(m.Altimeters))
+			for i, a := range m.Altimeters {
+				groups[i] = a.Group
+			}
+			m.addDecodeError("altimeter", strings.Join(groups, " "))
+			return
+		}
+	}
+}
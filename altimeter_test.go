@@ -0,0 +1,40 @@
+package avwx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecodeAltimeters(t *testing.T) {
+	tests := []struct {
+		raw       string
+		groups    []string
+		altimeter string
+		disagree  bool
+	}{
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A2992 Q1013", []string{"A2992", "Q1013"}, "29.92", false},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 Q1013 A2992", []string{"Q1013", "A2992"}, "29.91", false},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A2992 Q1020", []string{"A2992", "Q1020"}, "29.92", true},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A2992", []string{"A2992"}, "29.92", false},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if len(m.Altimeters) != len(tt.groups) {
+			t.Fatalf("%q: Altimeters = %+v, want groups %q", tt.raw, m.Altimeters, tt.groups)
+		}
+		for i, a := range m.Altimeters {
+			if a.Group != tt.groups[i] || math.Abs(a.InHg*hPaPerInHg-a.Hpa) > 1e-6 {
+				t.Errorf("%q: Altimeters[%d] = %+v, want group %q in consistent units", tt.raw, i, a, tt.groups[i])
+			}
+		}
+		if m.Altimeter != tt.altimeter {
+			t.Errorf("%q: Altimeter = %q, want %q from the first group", tt.raw, m.Altimeter, tt.altimeter)
+		}
+		if disagree := len(m.DecodeErrors) > 0; disagree != tt.disagree {
+			t.Errorf("%q: decode errors %v, want disagreement %v", tt.raw, m.DecodeErrors, tt.disagree)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package avwx
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the number of requests a batch fetch makes at once unless configured otherwise.
+const DefaultConcurrency = 8
+
+// WithConcurrency sets the number of requests a batch fetch makes at once.
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// FetchMetars fetches the current METAR for each station using DefaultClient.
+func FetchMetars(stations []string) []*MetarResponse {
+	return DefaultClient.FetchMetars(stations)
+}
+
+// FetchMetarsContext fetches the current METAR for each station using DefaultClient,
+// cancelling requests in flight when ctx is done.
+func FetchMetarsContext(ctx context.Context, stations []string) []*MetarResponse {
+	return DefaultClient.FetchMetarsContext(ctx, stations)
+}
+
+// FetchMetarsMap fetches the current METAR for each station using DefaultClient, keyed by station.
+func FetchMetarsMap(stations []string) map[string]*MetarResponse {
+	return DefaultClient.FetchMetarsMap(stations)
+}
+
+// FetchMetarsStream fetches the current METAR for each station using DefaultClient,
+// emitting each response as it completes.
+func FetchMetarsStream(stations []string) <-chan *MetarResponse {
+	return DefaultClient.FetchMetarsStream(stations)
+}
+
+// FetchMetars fetches the current METAR for each station concurrently, returning the responses
+// in the same order as the stations.
+func (c *Client) FetchMetars(stations []string) []*MetarResponse {
+	return c.FetchMetarsContext(context.Background(), stations)
+}
+
+// FetchMetarsContext fetches the current METAR for each station like FetchMetars, cancelling requests
+// in flight when ctx is done. Stations not yet fetched receive a response carrying ctx.Err().
+func (c *Client) FetchMetarsContext(ctx context.Context, stations []string) []*MetarResponse {
+	responses := make([]*MetarResponse, len(stations))
+	c.fetchEach(ctx, stations, func(i int, metarResp *MetarResponse) {
+		responses[i] = metarResp
+	})
+	return responses
+}
+
+// FetchMetarsMap fetches the current METAR for each station concurrently, keyed by station.
+// Duplicate stations are only fetched once.
+func (c *Client) FetchMetarsMap(stations []string) map[string]*MetarResponse {
+	unique := make([]string, 0, len(stations))
+	seen := make(map[string]bool, len(stations))
+	for _, station := range stations {
+		if !seen[station] {
+			seen[station] = true
+			unique = append(unique, station)
+		}
+	}
+
+	responses := make(map[string]*MetarResponse, len(unique))
+	var mu sync.Mutex
+	c.fetchEach(context.Background(), unique, func(i int, metarResp *MetarResponse) {
+		mu.Lock()
+		responses[unique[i]] = metarResp
+		mu.Unlock()
+	})
+	return responses
+}
+
+// FetchMetarsStream fetches the current METAR for each station concurrently, emitting each response
+// as soon as its fetch completes. The channel is closed once every station has been fetched; it is
+// buffered for the whole batch, so a consumer which stops reading early does not block the fetches.
+func (c *Client) FetchMetarsStream(stations []string) <-chan *MetarResponse {
+	out := make(chan *MetarResponse, len(stations))
+	go func() {
+		defer close(out)
+		c.fetchEach(context.Background(), stations, func(i int, metarResp *MetarResponse) {
+			out <- metarResp
+		})
+	}()
+	return out
+}
+
+// fetchEach fetches each station with at most the client's concurrency in flight at once,
+// passing each response to handle along with the station's index. Once ctx is done the
+// remaining stations are handled with a response carrying ctx.Err().
+func (c *Client) fetchEach(ctx context.Context, stations []string, handle func(i int, metarResp *MetarResponse)) {
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i, station := range stations {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			handle(i, &MetarResponse{ICAO: station, Error: newFetchError(station, ctx.Err())})
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, station string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			handle(i, c.FetchMetarContext(ctx, station))
+		}(i, station)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,392 @@
+package avwx
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the request timeout used when none is configured.
+const DefaultTimeout = 10 * time.Second
+
+// ErrTimeout is returned when a request does not complete within the client timeout.
+var ErrTimeout = errors.New("Request timed out")
+
+// ErrStationNotFound is returned when the API does not recognize the requested station.
+var ErrStationNotFound = errors.New("Station not found")
+
+// ErrNoReport is returned when the station is known but has no current report.
+var ErrNoReport = errors.New("No report available")
+
+// DefaultClient is the Client used by the package level fetch functions.
+var DefaultClient = NewClient()
+
+// Client fetches reports from the avwx API.
+// A Client reuses connections and is safe for concurrent use by multiple goroutines.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	historyURL  string
+	stationURL  string
+	pirepURL    string
+	options     []string
+	userAgent   string
+	timeout     *time.Duration
+	concurrency int
+	decoder     Decoder
+	stations    stationCache
+	logger      Logger
+
+	metricsHook        MetricsHook
+	stationCoordinates bool
+	skipDecode         bool
+	normalizeStations  bool
+	rawJSON            bool
+	source             Source
+	limiter            *rateLimiter
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// NewClient returns a Client with a transport tuned for repeated requests to the API.
+func NewClient(opts ...Option) *Client {
+	client := &Client{
+		httpClient:  &http.Client{Transport: newTransport(), Timeout: DefaultTimeout},
+		baseURL:     baseURL,
+		historyURL:  historyURL,
+		stationURL:  stationURL,
+		pirepURL:    pirepURL,
+		options:     strings.Split(defaultOptions, ","),
+		userAgent:   ClientVersion,
+		concurrency: DefaultConcurrency,
+		source:      AVWXSource{},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.timeout != nil {
+		// Set the timeout on a copy, as the http.Client may be shared with the caller.
+		httpClient := *client.httpClient
+		httpClient.Timeout = *client.timeout
+		client.httpClient = &httpClient
+	}
+	return client
+}
+
+// NewPooledClient returns a Client whose transport keeps up to maxIdleConns idle connections to the
+// API host, for polling many stations in quick succession. A maxIdleConns of zero or less keeps the
+// defaults of NewClient. Options are applied afterwards, so WithHTTPClient replaces the pooled transport.
+func NewPooledClient(maxIdleConns int, opts ...Option) *Client {
+	transport := newTransport()
+	if maxIdleConns > 0 {
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConns
+	}
+	pooled := func(c *Client) {
+		c.httpClient = &http.Client{Transport: transport, Timeout: DefaultTimeout}
+	}
+	return NewClient(append([]Option{pooled}, opts...)...)
+}
+
+// WithHTTPClient sets the http.Client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithReportOptions sets the API options requested with each METAR, such as "info", "translate",
+// "summary" or "speech". By default "info", "summary" and "speech" are requested.
+func WithReportOptions(opts ...string) Option {
+	return func(c *Client) {
+		c.options = opts
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request, identifying the application
+// to the API operator. ClientVersion is sent by default.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the time limit for each request, including reading the response body.
+// It overrides the timeout of an http.Client given with WithHTTPClient without modifying it.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = &d
+	}
+}
+
+// WithBaseURL sets the METAR endpoint requests are made against.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithNormalizeStations makes the client pass each station through FormatICAO before fetching, so
+// "sfo" is fetched as "KSFO". Stations which cannot be normalized fail with the reason.
+func WithNormalizeStations() Option {
+	return func(c *Client) {
+		c.normalizeStations = true
+	}
+}
+
+// WithRawJSON makes the client keep the response body on MetarResponse.RawJSON, for fields the
+// package does not decode. The body is not retained by default.
+func WithRawJSON() Option {
+	return func(c *Client) {
+		c.rawJSON = true
+	}
+}
+
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 16
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
+// FetchMetar fetches the current METAR for given station represented by a valid ICAO airport code.
+// The response Duration covers both the request and decoding.
+func (c *Client) FetchMetar(station string) *MetarResponse {
+	return c.fetchMetarResponse(context.Background(), station, c.options)
+}
+
+// FetchMetarContext fetches the current METAR for the given station, abandoning the request when ctx is done.
+func (c *Client) FetchMetarContext(ctx context.Context, station string) *MetarResponse {
+	return c.fetchMetarResponse(ctx, station, c.options)
+}
+
+// FetchMetarOptions fetches the current METAR for the given station, requesting the given API options,
+// such as "info", "translate", "summary" or "speech", in place of the client's options.
+func (c *Client) FetchMetarOptions(station string, opts ...string) *MetarResponse {
+	return c.fetchMetarResponse(context.Background(), station, opts)
+}
+
+func (c *Client) fetchMetarResponse(ctx context.Context, station string, opts []string) *MetarResponse {
+	start := time.Now()
+	metarResp := new(MetarResponse)
+	metarResp.ICAO = station
+	defer c.finish(metarResp, start)
+
+	if c.normalizeStations {
+		icao, err := FormatICAO(strings.TrimSpace(station))
+		if err != nil {
+			metarResp.Error = newFetchError(station, err)
+			return metarResp
+		}
+		station = icao
+		metarResp.ICAO = icao
+	}
+
+	metar, body, err := c.source.FetchMetar(ctx, c, station, opts)
+	if c.rawJSON {
+		metarResp.RawJSON = body
+	}
+	if err != nil {
+		metarResp.Error = newFetchError(station, err)
+		return metarResp
+	}
+	metarResp.Metar = *metar
+	if c.stationCoordinates {
+		if err := c.addCoordinates(&metarResp.Metar); err != nil {
+			metarResp.LocationError = err
+		}
+	}
+	return metarResp
+}
+
+// finish records how long a fetch took and reports it to the metrics hook.
+func (c *Client) finish(metarResp *MetarResponse, start time.Time) {
+	metarResp.Duration = time.Since(start)
+	if c.metricsHook != nil {
+		c.metricsHook(metarResp.ICAO, metarResp.Duration, metarResp.Error)
+	}
+}
+
+// fetchMetar fetches and decodes the METAR for the station, also returning the response body as received.
+func (c *Client) fetchMetar(ctx context.Context, station string, opts []string) (*Metar, []byte, error) {
+	resp, err := c.get(ctx, station, c.baseURL+station+"?options="+strings.Join(opts, ","))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, statusError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, wrapTimeout(err)
+	}
+
+	var metar Metar
+	if err := json.Unmarshal(body, &metar); err != nil {
+		return nil, body, err
+	}
+	if metar.Error != "" {
+		return nil, body, apiError(metar.Error)
+	}
+	if strings.TrimSpace(metar.RawReport) == "" {
+		return nil, body, ErrNoReport
+	}
+	if !c.skipDecode {
+		c.decoder.decodeMetar(&metar)
+	}
+	return &metar, body, nil
+}
+
+// parseMetar parses a raw report, decoding it with the client's decoder unless decoding is disabled.
+func (c *Client) parseMetar(raw string) (*Metar, error) {
+	if c.skipDecode {
+		return parseRaw(raw)
+	}
+	return c.decoder.ParseMetar(raw)
+}
+
+// statusError returns the error for a response without a report. The API answers 204 No Content
+// for a known station without a current report, and 400 or 404 with an error message naming the
+// station when it does not recognize the station.
+func statusError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return ErrNoReport
+	case http.StatusNotFound:
+		return ErrStationNotFound
+	case http.StatusBadRequest:
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&apiErr) == nil && isStationError(apiErr.Error) {
+			return fmt.Errorf("%w: %s", ErrStationNotFound, apiErr.Error)
+		}
+	}
+	return fmt.Errorf("Query failed: %s", resp.Status)
+}
+
+// apiError returns the error for an error message the API sent in place of a report, such as
+// "Station Lookup Error: KXXX does not appear to be a valid station".
+func apiError(message string) error {
+	if isStationError(message) {
+		return fmt.Errorf("%w: %s", ErrStationNotFound, message)
+	}
+	return fmt.Errorf("Query failed: %s", message)
+}
+
+// isStationError reports whether an API error message is about the requested station.
+func isStationError(message string) bool {
+	lower := strings.ToLower(message)
+	return strings.Contains(lower, "station") || strings.Contains(lower, "icao")
+}
+
+// get issues a GET request for the station identifying the client in the User-Agent header.
+func (c *Client) get(ctx context.Context, station, url string) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	var start time.Time
+	if c.logger != nil {
+		start = time.Now()
+	}
+	resp, err := c.httpClient.Do(req)
+	if c.logger != nil {
+		event := FetchEvent{Station: station, URL: url, Duration: time.Since(start), Err: err}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+		}
+		c.logger.LogFetch(event)
+	}
+	if err != nil {
+		return nil, wrapTimeout(err)
+	}
+
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, wrapTimeout(err)
+		}
+		resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.ContentLength = -1
+	}
+	return resp, nil
+}
+
+// gzipBody decompresses a response body, closing both the reader and the underlying body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (gb *gzipBody) Close() error {
+	gb.Reader.Close()
+	return gb.body.Close()
+}
+
+// FetchMetarDirect fetches the current METAR for the given station, returning the report and any error.
+func (c *Client) FetchMetarDirect(station string) (*Metar, error) {
+	metarResp := c.FetchMetar(station)
+	if metarResp.Error != nil {
+		return nil, metarResp.Error
+	}
+	return &metarResp.Metar, nil
+}
+
+// pingStation is the station requested by Ping.
+const pingStation = "KJFK"
+
+// Ping checks that the API is reachable using DefaultClient.
+func Ping(ctx context.Context) error {
+	return DefaultClient.Ping(ctx)
+}
+
+// Ping checks that the API is reachable and serving reports by requesting a single well-known station
+// without any report options. It returns nil when the service is healthy.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.get(ctx, pingStation, c.baseURL+pingStation)
+	if err != nil {
+		return newFetchError(pingStation, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newFetchError(pingStation, fmt.Errorf("Query failed: %s", resp.Status))
+	}
+	// Drain the body so the connection can be reused.
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func wrapTimeout(err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
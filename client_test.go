@@ -0,0 +1,191 @@
+package avwx
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testMetarJSON = `{
+	"Raw-Report": "KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002",
+	"Station": "KSFO",
+	"Time": "221756Z",
+	"Flight-Rules": "VFR",
+	"Wind-Direction": "280",
+	"Wind-Speed": "10",
+	"Visibility": "10",
+	"Cloud-List": [["FEW", "008"]],
+	"Temperature": "18",
+	"Dewpoint": "12",
+	"Altimeter": "3002"
+}`
+
+// metarHandler serves testMetarJSON for any station.
+func metarHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, testMetarJSON)
+}
+
+func TestClientReusesConnections(t *testing.T) {
+	var newConns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(metarHandler))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	const concurrency = 4
+	client := NewClient(WithBaseURL(srv.URL+"/"), WithConcurrency(concurrency))
+	stations := make([]string, 100)
+	for i := range stations {
+		stations[i] = "KSFO"
+	}
+	for _, metarResp := range client.FetchMetars(stations) {
+		if metarResp.Error != nil {
+			t.Fatalf("FetchMetars error: %v", metarResp.Error)
+		}
+	}
+
+	if n := atomic.LoadInt32(&newConns); n > concurrency {
+		t.Errorf("%d requests opened %d connections, want at most %d", len(stations), n, concurrency)
+	}
+}
+
+func BenchmarkFetchMetarParallel(b *testing.B) {
+	srv := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer srv.Close()
+	client := NewClient(WithBaseURL(srv.URL + "/"))
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if metarResp := client.FetchMetar("KSFO"); metarResp.Error != nil {
+				b.Fatal(metarResp.Error)
+			}
+		}
+	})
+}
+
+func TestFetchMetarDecodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer srv.Close()
+
+	metar, err := NewClient(WithBaseURL(srv.URL + "/")).FetchMetarDirect("KSFO")
+	if err != nil {
+		t.Fatalf("FetchMetarDirect error: %v", err)
+	}
+	if metar.WindSpeedKt != 10 || metar.TemperatureC != 18 || !strings.HasPrefix(metar.RawReport, "KSFO") {
+		t.Errorf("FetchMetarDirect decoded %+v", metar)
+	}
+}
+
+func TestFetchMetarTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := NewClient(WithBaseURL(srv.URL+"/"), WithTimeout(50*time.Millisecond))
+	start := time.Now()
+	metarResp := client.FetchMetar("KSFO")
+	if !errors.Is(metarResp.Error, ErrTimeout) {
+		t.Errorf("FetchMetar error = %v, want ErrTimeout", metarResp.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("FetchMetar took %v to time out", elapsed)
+	}
+}
+
+func TestWithTimeoutLeavesHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	for _, opts := range [][]Option{
+		{WithHTTPClient(httpClient), WithTimeout(time.Second)},
+		{WithTimeout(time.Second), WithHTTPClient(httpClient)},
+	} {
+		client := NewClient(opts...)
+		if client.httpClient.Timeout != time.Second {
+			t.Errorf("client timeout = %v, want %v", client.httpClient.Timeout, time.Second)
+		}
+		if client.httpClient == httpClient {
+			t.Error("client uses the caller's http.Client")
+		}
+	}
+	if httpClient.Timeout != time.Minute {
+		t.Errorf("caller's http.Client timeout changed to %v", httpClient.Timeout)
+	}
+
+	if client := NewClient(WithHTTPClient(httpClient)); client.httpClient != httpClient {
+		t.Error("client without WithTimeout does not use the caller's http.Client")
+	}
+}
+
+func TestWithRawJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer srv.Close()
+
+	metarResp := NewClient(WithBaseURL(srv.URL+"/"), WithRawJSON()).FetchMetar("KSFO")
+	if metarResp.Error != nil {
+		t.Fatalf("FetchMetar error: %v", metarResp.Error)
+	}
+	if string(metarResp.RawJSON) != testMetarJSON {
+		t.Errorf("RawJSON = %q, want the response body", metarResp.RawJSON)
+	}
+
+	metarResp = NewClient(WithBaseURL(srv.URL + "/")).FetchMetar("KSFO")
+	if metarResp.Error != nil {
+		t.Fatalf("FetchMetar error: %v", metarResp.Error)
+	}
+	if metarResp.RawJSON != nil {
+		t.Errorf("RawJSON = %q without WithRawJSON, want nil", metarResp.RawJSON)
+	}
+}
+
+func TestFetchMetarErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"no content", http.StatusNoContent, "", ErrNoReport},
+		{"empty report", http.StatusOK, `{"Raw-Report": "", "Station": "KSFO"}`, ErrNoReport},
+		{"not found", http.StatusNotFound, "", ErrStationNotFound},
+		{"bad station", http.StatusBadRequest, `{"error": "KXXX is not a valid ICAO or IATA code"}`, ErrStationNotFound},
+		{"lookup error", http.StatusOK, `{"Error": "Station Lookup Error: KXXX does not appear to be a valid station"}`, ErrStationNotFound},
+		{"other error", http.StatusOK, `{"Error": "Report parsing failed"}`, nil},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, tt.body)
+		}))
+		metarResp := NewClient(WithBaseURL(srv.URL + "/")).FetchMetar("KXXX")
+		srv.Close()
+
+		if metarResp.Error == nil {
+			t.Errorf("%s: FetchMetar succeeded", tt.name)
+			continue
+		}
+		if tt.want != nil && !errors.Is(metarResp.Error, tt.want) {
+			t.Errorf("%s: FetchMetar error = %v, want %v", tt.name, metarResp.Error, tt.want)
+		}
+		if tt.want == nil && (errors.Is(metarResp.Error, ErrNoReport) || errors.Is(metarResp.Error, ErrStationNotFound)) {
+			t.Errorf("%s: FetchMetar error = %v, want neither ErrNoReport nor ErrStationNotFound", tt.name, metarResp.Error)
+		}
+	}
+}
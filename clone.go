@@ -0,0 +1,36 @@
+package avwx
+
+// Clone returns a deep copy of the report which shares no slices with the original,
+// so it can be handed out from a cache and modified safely.
+func (m *Metar) Clone() Metar {
+	clone := *m
+
+	if m.CloudLayers != nil {
+		clone.CloudLayers = make([][]string, len(m.CloudLayers))
+		for i, layer := range m.CloudLayers {
+			clone.CloudLayers[i] = append([]string(nil), layer...)
+		}
+	}
+	clone.CloudLayersDec = append([]CloudLayerDec(nil), m.CloudLayersDec...)
+	clone.Conditions = append([]string(nil), m.Conditions...)
+	clone.ConditionsDec = append([]ConditionDec(nil), m.ConditionsDec...)
+	clone.RecentWeather = append([]ConditionDec(nil), m.RecentWeather...)
+	clone.Altimeters = append([]AltimeterDec(nil), m.Altimeters...)
+	clone.WindShear = append([]string(nil), m.WindShear...)
+	clone.SecondaryVisibility = append([]SecondaryVisibilityDec(nil), m.SecondaryVisibility...)
+	clone.DecodeErrors = append([]error(nil), m.DecodeErrors...)
+
+	clone.RemarksDec = m.RemarksDec.clone()
+	clone.Translations = m.Translations.clone()
+
+	if m.Trends != nil {
+		clone.Trends = make([]TrendDec, len(m.Trends))
+		for i, trend := range m.Trends {
+			trend.ConditionsDec = append([]ConditionDec(nil), trend.ConditionsDec...)
+			trend.CloudLayersDec = append([]CloudLayerDec(nil), trend.CloudLayersDec...)
+			clone.Trends[i] = trend
+		}
+	}
+
+	return clone
+}
@@ -0,0 +1,43 @@
+package avwx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	const raw = "KSFO 221756Z 28010G18KT 10SM -RA BR FEW008 BKN200 18/12 A3002 BECMG BKN015 RMK AO2 PK WND 28045/1955 WSHFT 1715 SLP167 P0012 60021 10183 20122 52032"
+	parse := func() Metar {
+		m, err := ParseMetar(raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", raw, err)
+		}
+		m.Translations.Remarks = map[string]string{"AO2": "Automated with precipitation sensor"}
+		return *m
+	}
+	original, want := parse(), parse()
+
+	clone := original.Clone()
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, original)
+	}
+
+	clone.CloudLayers[0][0] = "OVC"
+	clone.CloudLayersDec[0].HeightFtInt = 0
+	clone.Conditions[0] = "+TSRA"
+	clone.ConditionsDec[0].Desc = "HAIL"
+	clone.Trends[0].CloudLayersDec[0].HeightFtInt = 0
+	clone.RemarksDec.PeakWind.SpeedKt = 99
+	clone.RemarksDec.WindShift.Time = "0000"
+	*clone.RemarksDec.PrecipHourly = 9
+	*clone.RemarksDec.Precip3Or6Hour = 9
+	*clone.RemarksDec.MaxTemp6Hour = 99
+	*clone.RemarksDec.MinTemp6Hour = -99
+	clone.RemarksDec.PressureTendency.ChangeHpa = 99
+	clone.Translations.Remarks["AO2"] = "changed"
+	clone.Altimeters[0].InHg = 0
+
+	if !reflect.DeepEqual(original, want) {
+		t.Errorf("modifying the clone changed the original:\n got %+v\nwant %+v", original, want)
+	}
+}
@@ -0,0 +1,97 @@
+package avwx
+
+import (
+	"strings"
+	"sync"
+)
+
+// codesMu guards the conditions, coverage and cloudTypes maps against registration during decoding.
+var codesMu sync.RWMutex
+
+// RegisterCondition adds or replaces the description of a weather condition code, such as a regional
+// phenomenon used by a local feed. Reports decoded afterwards use the registered description.
+func RegisterCondition(code, desc string) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	conditions[code] = desc
+}
+
+// RegisterCoverage adds or replaces the description of a cloud coverage code.
+func RegisterCoverage(code, desc string) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	coverage[code] = desc
+}
+
+// RegisterCloudType adds or replaces the description of a cloud type code.
+func RegisterCloudType(code, desc string) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	cloudTypes[code] = desc
+}
+
+// LookupCondition returns the description of a weather condition code and whether the code is known.
+func LookupCondition(code string) (string, bool) {
+	return lookupCode(conditions, code)
+}
+
+// LookupCoverage returns the description of a cloud coverage code and whether the code is known.
+func LookupCoverage(code string) (string, bool) {
+	return lookupCode(coverage, code)
+}
+
+// LookupCloudType returns the description of a cloud type code and whether the code is known.
+func LookupCloudType(code string) (string, bool) {
+	return lookupCode(cloudTypes, code)
+}
+
+func lookupCode(codes map[string]string, code string) (string, bool) {
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+	desc, ok := codes[code]
+	return desc, ok
+}
+
+// isWeatherGroup reports whether a group is a present weather group made of known condition codes,
+// including any registered with RegisterCondition.
+func isWeatherGroup(group string) bool {
+	if len(strings.TrimLeft(group, "-+")) < 2 {
+		return false
+	}
+	if weatherPattern.MatchString(group) {
+		return true
+	}
+
+	group = strings.TrimPrefix(strings.TrimLeft(group, "-+"), "VC")
+	if len(group) > 2 && descriptors[group[:2]] {
+		group = group[2:]
+	}
+	if _, ok := LookupCondition(group); ok {
+		return true
+	}
+	if len(group)%2 != 0 {
+		return false
+	}
+	for _, code := range conditionCodes(group) {
+		if _, ok := LookupCondition(code); !ok {
+			return false
+		}
+	}
+	return group != ""
+}
+
+// isCloudGroup reports whether a group is a cloud layer with a known coverage and cloud type,
+// including any registered with RegisterCoverage and RegisterCloudType.
+func isCloudGroup(group string) bool {
+	match := cloudPattern.FindStringSubmatch(group)
+	if match == nil {
+		return false
+	}
+	if _, ok := LookupCoverage(match[1]); !ok && match[1] != "///" {
+		return false
+	}
+	if _, ok := LookupCloudType(match[3]); !ok && match[3] != "" && match[3] != "///" {
+		return false
+	}
+	return true
+}
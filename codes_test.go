@@ -0,0 +1,85 @@
+package avwx
+
+import (
+	"sync"
+	"testing"
+)
+
+// registerTestCodes registers custom codes for the duration of a test.
+func registerTestCodes(t *testing.T) {
+	RegisterCondition("ZZ", "VOLCANIC ERUPTION")
+	RegisterCoverage("BLU", "BLUE SKY")
+	RegisterCloudType("CUFRA", "CUMULUS FRACTUS")
+	t.Cleanup(func() {
+		codesMu.Lock()
+		defer codesMu.Unlock()
+		delete(conditions, "ZZ")
+		delete(coverage, "BLU")
+		delete(cloudTypes, "CUFRA")
+	})
+}
+
+func TestRegisterCodes(t *testing.T) {
+	const raw = "KSFO 221756Z 28010KT 10SM -ZZ BLU030CUFRA 18/12 A3002"
+	if m, err := ParseMetar(raw); err != nil || len(m.Conditions) != 0 || len(m.CloudLayers) != 0 {
+		t.Fatalf("ParseMetar(%q) before registering = %+v, %v, want unknown groups skipped", raw, m, err)
+	}
+
+	registerTestCodes(t)
+	if desc, ok := LookupCondition("ZZ"); !ok || desc != "VOLCANIC ERUPTION" {
+		t.Errorf("LookupCondition(\"ZZ\") = %q, %v", desc, ok)
+	}
+
+	m, err := ParseMetar(raw)
+	if err != nil {
+		t.Fatalf("ParseMetar(%q) error: %v", raw, err)
+	}
+	if want := (ConditionDec{Modifier: "LIGHT", Desc: "VOLCANIC ERUPTION"}); len(m.ConditionsDec) != 1 || m.ConditionsDec[0] != want {
+		t.Errorf("ConditionsDec = %+v, want [%+v]", m.ConditionsDec, want)
+	}
+	if want := (CloudLayerDec{Coverage: "BLUE SKY", HeightFt: "3000", HeightFtInt: 3000, Type: "CUMULUS FRACTUS"}); len(m.CloudLayersDec) != 1 || m.CloudLayersDec[0] != want {
+		t.Errorf("CloudLayersDec = %+v, want [%+v]", m.CloudLayersDec, want)
+	}
+}
+
+func TestConcurrentDecode(t *testing.T) {
+	const raw = "KSFO 221756Z 28010KT 10SM -RA BR FEW008 BKN200CB 18/12 A3002"
+	t.Cleanup(func() {
+		codesMu.Lock()
+		defer codesMu.Unlock()
+		delete(conditions, "ZY")
+	})
+
+	// One Decoder is shared by every goroutine, as its documentation allows.
+	d := new(Decoder)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m, err := d.ParseMetar(raw)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				metar := Metar{Conditions: m.Conditions, CloudLayers: m.CloudLayers, RawReport: raw}
+				d.decodeMetar(&metar)
+				if len(metar.ConditionsDec) != 2 || metar.ConditionsDec[0].Desc != "RAIN" {
+					t.Errorf("decoded conditions %+v", metar.ConditionsDec)
+					return
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 100; j++ {
+			RegisterCondition("ZY", "TEST")
+			RegisterCoverage("FEW", "FEW")
+			RegisterCloudType("CB", "CUMULONIMBUS")
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,73 @@
+package avwx
+
+import (
+	"fmt"
+	"math"
+)
+
+var directionNames = map[string]string{
+	"N":   "North",
+	"NNE": "North-Northeast",
+	"NE":  "Northeast",
+	"ENE": "East-Northeast",
+	"E":   "East",
+	"ESE": "East-Southeast",
+	"SE":  "Southeast",
+	"SSE": "South-Southeast",
+	"S":   "South",
+	"SSW": "South-Southwest",
+	"SW":  "Southwest",
+	"WSW": "West-Southwest",
+	"W":   "West",
+	"WNW": "West-Northwest",
+	"NW":  "Northwest",
+	"NNW": "North-Northwest",
+}
+
+// GetDirectionName returns the full English name of the 16-point compass direction, such as "North-Northeast".
+// It uses the same boundaries as GetDirectionDesc.
+func GetDirectionName(degrees int64) string {
+	return directionNames[GetDirectionDesc(degrees)]
+}
+
+// GetDirectionDescFloat returns the 16-point compass description for a fractional direction in degrees,
+// along with the direction normalized into the range [0, 360).
+func GetDirectionDescFloat(degrees float64) (string, float64) {
+	normalized := math.Mod(degrees, 360)
+	if normalized < 0 {
+		normalized += 360
+	}
+	return GetDirectionDesc(int64(math.Round(normalized))), normalized
+}
+
+// Direction is a 16-point compass direction together with its normalized degrees.
+type Direction struct {
+	Degrees int64
+	Desc    string
+	// Exact is true when Degrees lies exactly on the compass point rather than between points.
+	Exact bool
+}
+
+// String formats the direction as its description and degrees, such as "NE (045°)".
+func (d Direction) String() string {
+	return fmt.Sprintf("%s (%03d°)", d.Desc, d.Degrees)
+}
+
+// GetDirection returns the compass direction for any number of degrees, normalizing the value
+// into the range [0, 360) so that 720 is N and -10 is N.
+func GetDirection(degrees int64) Direction {
+	normalized := (degrees%360 + 360) % 360
+	return Direction{
+		Degrees: normalized,
+		Desc:    GetDirectionDesc(normalized),
+		// Compass points are 22.5 degrees apart.
+		Exact: normalized*10%225 == 0,
+	}
+}
+
+// GetDirectionDescDetailed returns the compass description for any number of degrees and whether
+// the direction lies exactly on that compass point.
+func GetDirectionDescDetailed(degrees int64) (label string, exact bool) {
+	direction := GetDirection(degrees)
+	return direction.Desc, direction.Exact
+}
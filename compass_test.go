@@ -0,0 +1,92 @@
+package avwx
+
+import "testing"
+
+func TestGetDirection(t *testing.T) {
+	tests := []struct {
+		degrees int64
+		want    Direction
+	}{
+		{0, Direction{0, "N", true}},
+		{45, Direction{45, "NE", true}},
+		{360, Direction{0, "N", true}},
+		{720, Direction{0, "N", true}},
+		{-10, Direction{350, "N", false}},
+		{349, Direction{349, "NNW", false}},
+		{350, Direction{350, "N", false}},
+		{-45, Direction{315, "NW", true}},
+		{405, Direction{45, "NE", true}},
+		{202, Direction{202, "SSW", false}},
+	}
+	for _, tt := range tests {
+		if got := GetDirection(tt.degrees); got != tt.want {
+			t.Errorf("GetDirection(%d) = %+v, want %+v", tt.degrees, got, tt.want)
+		}
+		label, exact := GetDirectionDescDetailed(tt.degrees)
+		if label != tt.want.Desc || exact != tt.want.Exact {
+			t.Errorf("GetDirectionDescDetailed(%d) = %q, %v, want %q, %v", tt.degrees, label, exact, tt.want.Desc, tt.want.Exact)
+		}
+	}
+}
+
+func TestDirectionString(t *testing.T) {
+	if got, want := GetDirection(45).String(), "NE (045°)"; got != want {
+		t.Errorf("GetDirection(45).String() = %q, want %q", got, want)
+	}
+}
+
+// switchDirectionDesc is the switch GetDirectionDesc used before its lookup table.
+func switchDirectionDesc(degrees int64) string {
+	degrees = (degrees%360 + 360) % 360
+
+	switch {
+	case degrees > 349 || degrees <= 11:
+		return "N"
+	case degrees > 11 && degrees <= 34:
+		return "NNE"
+	case degrees > 34 && degrees <= 56:
+		return "NE"
+	case degrees > 56 && degrees <= 79:
+		return "ENE"
+	case degrees > 79 && degrees <= 101:
+		return "E"
+	case degrees > 101 && degrees <= 124:
+		return "ESE"
+	case degrees > 124 && degrees <= 146:
+		return "SE"
+	case degrees > 146 && degrees <= 169:
+		return "SSE"
+	case degrees > 169 && degrees <= 191:
+		return "S"
+	case degrees > 191 && degrees <= 214:
+		return "SSW"
+	case degrees > 214 && degrees <= 236:
+		return "SW"
+	case degrees > 236 && degrees <= 259:
+		return "WSW"
+	case degrees > 259 && degrees <= 281:
+		return "W"
+	case degrees > 281 && degrees <= 304:
+		return "WNW"
+	case degrees > 304 && degrees <= 326:
+		return "NW"
+	case degrees > 326 && degrees <= 349:
+		return "NNW"
+	default:
+		return ""
+	}
+}
+
+func TestGetDirectionDescTable(t *testing.T) {
+	for degrees := int64(-1000); degrees <= 1000; degrees++ {
+		if got, want := GetDirectionDesc(degrees), switchDirectionDesc(degrees); got != want {
+			t.Errorf("GetDirectionDesc(%d) = %q, want %q", degrees, got, want)
+		}
+	}
+}
+
+func BenchmarkGetDirectionDesc(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GetDirectionDesc(int64(i % 720))
+	}
+}
@@ -0,0 +1,102 @@
+package avwx
+
+import "strconv"
+
+// TemperatureUnit selects which temperature fields are populated while decoding.
+type TemperatureUnit int
+
+const (
+	// UnitBoth populates both the Celsius and Fahrenheit fields.
+	UnitBoth TemperatureUnit = iota
+	UnitCelsius
+	UnitFahrenheit
+	UnitKelvin
+)
+
+// Decoder holds the settings used to decode reports.
+// The zero value decodes with the package defaults.
+//
+// Decoding only reads the Decoder, so one Decoder may be shared by many goroutines as long as its
+// settings are not changed while it is in use. The code tables it decodes with are shared by the
+// package and guarded against concurrent RegisterCondition, RegisterCoverage and RegisterCloudType calls.
+type Decoder struct {
+	TemperatureUnit TemperatureUnit
+
+	// TemperaturePrecision is the number of decimal places in formatted temperatures.
+	// When nil, Celsius and Fahrenheit use 1 and Kelvin uses 2.
+	TemperaturePrecision *int
+	// AltimeterPrecision is the number of decimal places in the formatted inHg altimeter.
+	// When nil, 2 is used.
+	AltimeterPrecision *int
+	// Units selects the units used by the String and Report output of decoded reports.
+	Units Units
+}
+
+// Precision returns a pointer to places for use as a Decoder precision.
+func Precision(places int) *int {
+	return &places
+}
+
+// WithTemperatureUnit sets which temperature fields the client populates.
+func WithTemperatureUnit(unit TemperatureUnit) Option {
+	return func(c *Client) {
+		c.decoder.TemperatureUnit = unit
+	}
+}
+
+// WithTemperaturePrecision sets the number of decimal places in the client's formatted temperatures.
+func WithTemperaturePrecision(places int) Option {
+	return func(c *Client) {
+		c.decoder.TemperaturePrecision = Precision(places)
+	}
+}
+
+// WithAltimeterPrecision sets the number of decimal places in the client's formatted inHg altimeter.
+func WithAltimeterPrecision(places int) Option {
+	return func(c *Client) {
+		c.decoder.AltimeterPrecision = Precision(places)
+	}
+}
+
+// WithUnits sets the units used by the String and Report output of the client's reports. The metric
+// fields WindSpeedKmh, WindGustKmh, VisibilityM and AltimeterHpa are populated whatever the units.
+func WithUnits(units Units) Option {
+	return func(c *Client) {
+		c.decoder.Units = units
+	}
+}
+
+// WithoutDecoding makes the client return reports as the API delivered them, leaving the fields
+// the package decodes itself, such as TemperatureC, VisibilitySM and CloudLayersDec, empty.
+// Raw reports fetched by FetchMetarAt or NOAASource are split into the API's fields but likewise
+// left undecoded.
+func WithoutDecoding() Option {
+	return func(c *Client) {
+		c.skipDecode = true
+	}
+}
+
+func precision(places *int, def int) int {
+	if places == nil {
+		return def
+	}
+	return *places
+}
+
+// formatTemperature formats a Celsius value into the Celsius, Fahrenheit and Kelvin fields
+// requested by the decoder, leaving the others empty.
+func (d *Decoder) formatTemperature(c float64) (celsius, fahrenheit, kelvin string) {
+	places := precision(d.TemperaturePrecision, 1)
+	switch d.TemperatureUnit {
+	case UnitCelsius:
+		celsius = strconv.FormatFloat(c, 'f', places, 64)
+	case UnitFahrenheit:
+		fahrenheit = strconv.FormatFloat(cToF(c), 'f', places, 64)
+	case UnitKelvin:
+		kelvin = strconv.FormatFloat(cToK(c), 'f', precision(d.TemperaturePrecision, 2), 64)
+	default:
+		celsius = strconv.FormatFloat(c, 'f', places, 64)
+		fahrenheit = strconv.FormatFloat(cToF(c), 'f', places, 64)
+	}
+	return celsius, fahrenheit, kelvin
+}
@@ -0,0 +1,120 @@
+package avwx
+
+import "testing"
+
+func TestTemperatureUnit(t *testing.T) {
+	tests := []struct {
+		unit                        TemperatureUnit
+		celsius, fahrenheit, kelvin string
+	}{
+		{UnitBoth, "-5.0", "23.0", ""},
+		{UnitCelsius, "-5.0", "", ""},
+		{UnitFahrenheit, "", "23.0", ""},
+		{UnitKelvin, "", "", "268.15"},
+	}
+	for _, tt := range tests {
+		d := &Decoder{TemperatureUnit: tt.unit}
+		m, err := d.ParseMetar("KSFO 221756Z 28010KT 10SM M05/M10 A3002")
+		if err != nil {
+			t.Fatalf("ParseMetar error: %v", err)
+		}
+		if m.Temperature != tt.celsius || m.TemperatureF != tt.fahrenheit || m.TemperatureKelvin != tt.kelvin {
+			t.Errorf("unit %d: temperature C, F, K = %q, %q, %q, want %q, %q, %q", tt.unit,
+				m.Temperature, m.TemperatureF, m.TemperatureKelvin, tt.celsius, tt.fahrenheit, tt.kelvin)
+		}
+		if m.TemperatureC != -5 || m.DewpointC != -10 {
+			t.Errorf("unit %d: numeric temperature/dewpoint = %v/%v, want -5/-10", tt.unit, m.TemperatureC, m.DewpointC)
+		}
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	const raw = "KSFO 221756Z 28010KT 10SM FEW008 18/M05 A3002 RMK AO2 T01831052"
+	tests := []struct {
+		name                  string
+		decoder               Decoder
+		temp, tempF, dewpoint string
+		kelvin                string
+		altimeter             string
+	}{
+		{"default", Decoder{}, "18.0", "64.4", "-5.0", "", "30.02"},
+		{"0 places", Decoder{TemperaturePrecision: Precision(0), AltimeterPrecision: Precision(0)}, "18", "64", "-5", "", "30"},
+		{"2 places", Decoder{TemperaturePrecision: Precision(2), AltimeterPrecision: Precision(2)}, "18.00", "64.40", "-5.00", "", "30.02"},
+		{"Kelvin 0 places", Decoder{TemperatureUnit: UnitKelvin, TemperaturePrecision: Precision(0)}, "", "", "", "291", "30.02"},
+	}
+	for _, tt := range tests {
+		m, err := tt.decoder.ParseMetar(raw)
+		if err != nil {
+			t.Fatalf("ParseMetar error: %v", err)
+		}
+		if m.Temperature != tt.temp || m.TemperatureF != tt.tempF || m.Dewpoint != tt.dewpoint || m.TemperatureKelvin != tt.kelvin {
+			t.Errorf("%s: temperature, F, dewpoint, K = %q, %q, %q, %q, want %q, %q, %q, %q", tt.name,
+				m.Temperature, m.TemperatureF, m.Dewpoint, m.TemperatureKelvin, tt.temp, tt.tempF, tt.dewpoint, tt.kelvin)
+		}
+		if m.Altimeter != tt.altimeter {
+			t.Errorf("%s: Altimeter = %q, want %q", tt.name, m.Altimeter, tt.altimeter)
+		}
+		if m.TemperatureC != 18.3 || m.DewpointC != -5.2 || m.AltimeterInHg != 30.02 {
+			t.Errorf("%s: numeric values %v, %v, %v changed by precision", tt.name, m.TemperatureC, m.DewpointC, m.AltimeterInHg)
+		}
+	}
+}
+
+func TestWithPrecisionOptions(t *testing.T) {
+	c := NewClient(WithTemperaturePrecision(0), WithAltimeterPrecision(2))
+	if c.decoder.TemperaturePrecision == nil || *c.decoder.TemperaturePrecision != 0 {
+		t.Errorf("TemperaturePrecision = %v, want 0", c.decoder.TemperaturePrecision)
+	}
+	if c.decoder.AltimeterPrecision == nil || *c.decoder.AltimeterPrecision != 2 {
+		t.Errorf("AltimeterPrecision = %v, want 2", c.decoder.AltimeterPrecision)
+	}
+}
+
+func TestMetricFields(t *testing.T) {
+	tests := []struct {
+		raw                       string
+		speedKmh, gustKmh         string
+		visibilityM, altimeterHpa string
+	}{
+		{"KSFO 221756Z 28010G18KT 10SM FEW008 18/12 A3002", "19", "33", "9999", "1017"},
+		{"KORD 221751Z 36005KT 1/2SM FG VV002 M02/M03 A2992", "9", "", "0805", "1013"},
+		{"EGLL 221750Z 24015KT 4000 SCT040 12/06 Q0998", "28", "", "4000", "998"},
+		{"EGLL 221750Z 00000KT CAVOK 12/06 Q1013", "0", "", "9999", "1013"},
+		{"KDEN 221753Z 17012KT ////SM OVC008 M01/M02 A2985", "22", "", "", "1011"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.WindSpeedKmh != tt.speedKmh || m.WindGustKmh != tt.gustKmh || m.VisibilityM != tt.visibilityM || m.AltimeterHpa != tt.altimeterHpa {
+			t.Errorf("%q: km/h, gust km/h, meters, hPa = %q, %q, %q, %q, want %q, %q, %q, %q", tt.raw,
+				m.WindSpeedKmh, m.WindGustKmh, m.VisibilityM, m.AltimeterHpa, tt.speedKmh, tt.gustKmh, tt.visibilityM, tt.altimeterHpa)
+		}
+	}
+}
+
+func TestWithUnits(t *testing.T) {
+	c := NewClient(WithUnits(Metric))
+	if c.decoder.Units != Metric {
+		t.Errorf("WithUnits(Metric) set Units to %v", c.decoder.Units)
+	}
+
+	// The units select the output of String and Report, not the API's fields.
+	const raw = "KSFO 221756Z 28010G18KT 10SM FEW008 18/12 A3002"
+	imperial, _ := ParseMetar(raw)
+	metric, err := c.decoder.ParseMetar(raw)
+	if err != nil {
+		t.Fatalf("ParseMetar error: %v", err)
+	}
+	if metric.Temperature != "18.0" || metric.TemperatureF != "64.4" || metric.WindSpeed != "10" || metric.WindGust != "18" ||
+		metric.Visibility != "10" || metric.Altimeter != "30.02" {
+		t.Errorf("Metric units changed the API's fields: %+v", metric)
+	}
+	if got, _ := metric.GustFactor(); got != 8 {
+		t.Errorf("GustFactor() = %d, want 8", got)
+	}
+	if imperial.String() == metric.String() {
+		t.Errorf("String() is %q for both units", metric.String())
+	}
+}
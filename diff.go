@@ -0,0 +1,59 @@
+package avwx
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldChange is a field which differs between two reports, with its value in each.
+// An empty value means the field was not reported.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Diff compares the report with a later report for the same station and returns the operationally
+// significant fields which changed: flight rules, ceiling, sky, visibility, wind, temperature and
+// weather conditions. Sky and conditions are compared by their decoded descriptions, such as
+// "BROKEN 2000" or "LIGHT RAIN", rather than the raw groups.
+func (m *Metar) Diff(other Metar) []FieldChange {
+	var changes []FieldChange
+	compare := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+	compare("FlightRules", m.FlightRules, other.FlightRules)
+	compare("Ceiling", m.ceilingFt(), other.ceilingFt())
+	compare("Sky", m.skyDesc(), other.skyDesc())
+	compare("Visibility", m.Visibility, other.Visibility)
+	compare("WindDirection", m.WindDirection, other.WindDirection)
+	compare("WindSpeed", m.WindSpeed, other.WindSpeed)
+	compare("WindGust", m.WindGust, other.WindGust)
+	compare("Temperature", m.Temperature, other.Temperature)
+	compare("Conditions", strings.Join(m.WeatherDescriptions(), ", "), strings.Join(other.WeatherDescriptions(), ", "))
+	return changes
+}
+
+func (m *Metar) ceilingFt() string {
+	if layer, ok := m.Ceiling(); ok {
+		return strconv.Itoa(layer.HeightFtInt)
+	}
+	return ""
+}
+
+// skyDesc describes the decoded cloud layers, such as "FEW 2000, BROKEN 8000 CUMULONIMBUS".
+func (m *Metar) skyDesc() string {
+	layers := make([]string, 0, len(m.CloudLayersDec))
+	for _, layer := range m.CloudLayersDec {
+		var words []string
+		for _, word := range []string{layer.Coverage, layer.HeightFt, layer.Type} {
+			if word != "" {
+				words = append(words, word)
+			}
+		}
+		layers = append(layers, strings.Join(words, " "))
+	}
+	return strings.Join(layers, ", ")
+}
@@ -0,0 +1,39 @@
+package avwx
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	vfr, err := ParseMetar("KSFO 221656Z 28010KT 10SM FEW030 18/12 A3002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ifr, err := ParseMetar("KSFO 221756Z 30015G25KT 2SM -RA BR OVC008 16/14 A2998")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FieldChange{
+		{"FlightRules", "VFR", "IFR"},
+		{"Ceiling", "", "800"},
+		{"Sky", "FEW 3000", "OVERCAST 800"},
+		{"Visibility", "10", "2"},
+		{"WindDirection", "280", "300"},
+		{"WindSpeed", "10", "15"},
+		{"WindGust", "", "25"},
+		{"Temperature", "18.0", "16.0"},
+		{"Conditions", "", "LIGHT RAIN, MIST"},
+	}
+	changes := vfr.Diff(*ifr)
+	if len(changes) != len(want) {
+		t.Fatalf("Diff = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Diff()[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if changes := vfr.Diff(*vfr); len(changes) != 0 {
+		t.Errorf("Diff of a report with itself = %+v, want none", changes)
+	}
+}
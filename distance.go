@@ -0,0 +1,22 @@
+package avwx
+
+import "math"
+
+// earthRadiusNM is the mean radius of the Earth in nautical miles.
+const earthRadiusNM = 3440.065
+
+// Distance returns the great-circle distance in nautical miles between two points given in decimal degrees,
+// using the haversine formula.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const toRadians = math.Pi / 180
+	dLat := (lat2 - lat1) * toRadians
+	dLon := (lon2 - lon1) * toRadians
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRadians)*math.Cos(lat2*toRadians)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusNM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// DistanceTo returns the great-circle distance in nautical miles to another station.
+func (s Station) DistanceTo(other Station) float64 {
+	return Distance(s.Latitude, s.Longitude, other.Latitude, other.Longitude)
+}
@@ -0,0 +1,35 @@
+package avwx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	var (
+		ksfo = Station{Latitude: 37.619, Longitude: -122.375}
+		klax = Station{Latitude: 33.9425, Longitude: -118.408}
+		kjfk = Station{Latitude: 40.6398, Longitude: -73.7789}
+		egll = Station{Latitude: 51.4706, Longitude: -0.4619}
+		wsss = Station{Latitude: 1.3502, Longitude: 103.994}
+	)
+	tests := []struct {
+		name     string
+		from, to Station
+		wantNM   float64
+	}{
+		{"KSFO-KLAX", ksfo, klax, 293},
+		{"KJFK-EGLL", kjfk, egll, 2991},
+		{"EGLL-WSSS", egll, wsss, 5900},
+		{"same station", ksfo, ksfo, 0},
+	}
+	for _, tt := range tests {
+		got := tt.from.DistanceTo(tt.to)
+		if math.Abs(got-tt.wantNM) > math.Max(1, tt.wantNM*0.005) {
+			t.Errorf("%s: DistanceTo = %.1f NM, want about %.0f", tt.name, got, tt.wantNM)
+		}
+		if back := tt.to.DistanceTo(tt.from); math.Abs(back-got) > 1e-9 {
+			t.Errorf("%s: distance back = %v, want %v", tt.name, back, got)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package avwx
+
+import "strings"
+
+// IsVFR reports whether the station is reporting visual flight rules.
+func (m *Metar) IsVFR() bool {
+	return m.hasFlightRules("VFR")
+}
+
+// IsMVFR reports whether the station is reporting marginal visual flight rules.
+func (m *Metar) IsMVFR() bool {
+	return m.hasFlightRules("MVFR")
+}
+
+// IsIFR reports whether the station is reporting instrument flight rules.
+func (m *Metar) IsIFR() bool {
+	return m.hasFlightRules("IFR")
+}
+
+// IsLIFR reports whether the station is reporting low instrument flight rules.
+func (m *Metar) IsLIFR() bool {
+	return m.hasFlightRules("LIFR")
+}
+
+// hasFlightRules reports whether the report's flight rules are rules. When the API gave none, they are
+// computed from the decoded ceiling and visibility.
+func (m *Metar) hasFlightRules(rules string) bool {
+	reported := m.FlightRules
+	if strings.TrimSpace(reported) == "" {
+		reported = m.computeFlightRules()
+	}
+	return ParseFlightRules(reported) == ParseFlightRules(rules)
+}
+
+// FlightRulesCategory is a flight rules category, ordered from least to most restrictive.
+type FlightRulesCategory int
+
+// Flight rules categories, with FlightRulesUnknown for a missing or unrecognized value.
+const (
+	FlightRulesUnknown FlightRulesCategory = iota
+	FlightRulesVFR
+	FlightRulesMVFR
+	FlightRulesIFR
+	FlightRulesLIFR
+)
+
+var flightRulesNames = [...]string{"", "VFR", "MVFR", "IFR", "LIFR"}
+
+// ParseFlightRules parses a flight rules string such as "MVFR", ignoring case and surrounding space.
+// Unrecognized values give FlightRulesUnknown.
+func ParseFlightRules(rules string) FlightRulesCategory {
+	rules = strings.ToUpper(strings.TrimSpace(rules))
+	for i, name := range flightRulesNames {
+		if i > 0 && name == rules {
+			return FlightRulesCategory(i)
+		}
+	}
+	return FlightRulesUnknown
+}
+
+// String returns the category's abbreviation, such as "IFR", or "" when unknown.
+func (c FlightRulesCategory) String() string {
+	if c < 0 || int(c) >= len(flightRulesNames) {
+		return ""
+	}
+	return flightRulesNames[c]
+}
+
+// MarshalText encodes the category as its abbreviation.
+func (c FlightRulesCategory) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a category from its abbreviation.
+func (c *FlightRulesCategory) UnmarshalText(text []byte) error {
+	*c = ParseFlightRules(string(text))
+	return nil
+}
+
+// FlightRulesFor returns the flight rules category for a ceiling and visibility using the thresholds
+// the API applies to METARs, so forecast periods can be categorized alike. hasCeiling is false when
+// no broken, overcast or obscured layer is forecast.
+func FlightRulesFor(ceilingFt int, hasCeiling bool, visibilitySM float64) string {
+	switch {
+	case hasCeiling && ceilingFt < 500 || visibilitySM < 1:
+		return "LIFR"
+	case hasCeiling && ceilingFt < 1000 || visibilitySM < 3:
+		return "IFR"
+	case hasCeiling && ceilingFt <= 3000 || visibilitySM <= 5:
+		return "MVFR"
+	}
+	return "VFR"
+}
+
+// computeFlightRules returns the flight rules for the decoded ceiling and visibility using FlightRulesFor,
+// or "" when no visibility was decoded or a layer's coverage or height is reported as slashes, which
+// leaves the ceiling unknown.
+func (m *Metar) computeFlightRules() string {
+	if m.VisibilityModifier == "" {
+		return ""
+	}
+	for _, layer := range m.CloudLayers {
+		if len(layer) > 0 && layer[0] == "///" || len(layer) > 1 && layer[1] == "///" {
+			return ""
+		}
+	}
+	ceiling, hasCeiling := m.Ceiling()
+	ceilingFt := 0
+	if hasCeiling {
+		ceilingFt = ceiling.HeightFtInt
+	}
+	return FlightRulesFor(ceilingFt, hasCeiling, m.VisibilitySM)
+}
+
+// WorstFlightRules returns the most restrictive of the given categories, such as those of each period
+// of a forecast. Unknown categories are ignored and an empty string is returned when none are known.
+func WorstFlightRules(rules ...string) string {
+	worst := FlightRulesUnknown
+	for _, r := range rules {
+		if category := ParseFlightRules(r); category > worst {
+			worst = category
+		}
+	}
+	return worst.String()
+}
@@ -0,0 +1,113 @@
+package avwx
+
+import "testing"
+
+func TestFlightRulesPredicates(t *testing.T) {
+	tests := []struct {
+		rules                string
+		vfr, mvfr, ifr, lifr bool
+	}{
+		{"VFR", true, false, false, false},
+		{"mvfr", false, true, false, false},
+		{" IFR ", false, false, true, false},
+		{"Lifr", false, false, false, true},
+		{"", false, false, false, false},
+		{"XYZ", false, false, false, false},
+	}
+	for _, tt := range tests {
+		m := &Metar{FlightRules: tt.rules}
+		if m.IsVFR() != tt.vfr || m.IsMVFR() != tt.mvfr || m.IsIFR() != tt.ifr || m.IsLIFR() != tt.lifr {
+			t.Errorf("FlightRules %q: IsVFR, IsMVFR, IsIFR, IsLIFR = %v, %v, %v, %v, want %v, %v, %v, %v", tt.rules,
+				m.IsVFR(), m.IsMVFR(), m.IsIFR(), m.IsLIFR(), tt.vfr, tt.mvfr, tt.ifr, tt.lifr)
+		}
+	}
+}
+
+func TestFlightRulesComputedFallback(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002", "VFR"},
+		{"KSFO 221756Z 28010KT 4SM BR BKN025 18/12 A3002", "MVFR"},
+		{"KSFO 221756Z 28010KT 2SM BR OVC008 18/12 A3002", "IFR"},
+		{"KSFO 221756Z 28010KT 1/4SM FG VV001 18/12 A3002", "LIFR"},
+		// An unknown sky leaves the ceiling, and so the flight rules, unknown.
+		{"KXYZ 221756Z AUTO 28010KT 10SM ////// 18/12 A3002", ""},
+		{"KXYZ 221756Z AUTO 28010KT 10SM BKN/// 18/12 A3002", ""},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		// Reports from the API without flight rules fall back to the computed category.
+		m.FlightRules = ""
+		if got := m.computeFlightRules(); got != tt.want || tt.want != "" && !m.hasFlightRules(tt.want) {
+			t.Errorf("%q: computed flight rules = %q, want %q", tt.raw, m.computeFlightRules(), tt.want)
+		}
+	}
+
+	if m, _ := ParseMetar("KXYZ 221756Z AUTO 28010KT 10SM ////// 18/12 A3002"); m.FlightRules != "" || m.IsVFR() {
+		t.Errorf("report with an unknown sky has flight rules %q", m.FlightRules)
+	}
+	if m := (&Metar{}); m.IsVFR() || m.IsLIFR() {
+		t.Error("report without visibility matches a flight rules category")
+	}
+}
+
+func TestFlightRulesFor(t *testing.T) {
+	tests := []struct {
+		ceilingFt    int
+		hasCeiling   bool
+		visibilitySM float64
+		want         string
+	}{
+		{0, false, 10, "VFR"},
+		{3500, true, 6, "VFR"},
+		{3000, true, 10, "MVFR"},
+		{5000, true, 5, "MVFR"},
+		{900, true, 10, "IFR"},
+		{5000, true, 2, "IFR"},
+		{400, true, 10, "LIFR"},
+		{0, false, 0.5, "LIFR"},
+	}
+	for _, tt := range tests {
+		if got := FlightRulesFor(tt.ceilingFt, tt.hasCeiling, tt.visibilitySM); got != tt.want {
+			t.Errorf("FlightRulesFor(%d, %v, %v) = %q, want %q", tt.ceilingFt, tt.hasCeiling, tt.visibilitySM, got, tt.want)
+		}
+	}
+}
+
+func TestParseFlightRules(t *testing.T) {
+	tests := []struct {
+		rules string
+		want  FlightRulesCategory
+		str   string
+	}{
+		{"VFR", FlightRulesVFR, "VFR"},
+		{"MVFR", FlightRulesMVFR, "MVFR"},
+		{"IFR", FlightRulesIFR, "IFR"},
+		{"LIFR", FlightRulesLIFR, "LIFR"},
+		{" lifr ", FlightRulesLIFR, "LIFR"},
+		{"", FlightRulesUnknown, ""},
+		{"SVFR", FlightRulesUnknown, ""},
+	}
+	for _, tt := range tests {
+		got := ParseFlightRules(tt.rules)
+		if got != tt.want || got.String() != tt.str {
+			t.Errorf("ParseFlightRules(%q) = %d (%q), want %d (%q)", tt.rules, got, got.String(), tt.want, tt.str)
+		}
+
+		// The decoder keeps the API's string and adds the category.
+		m := decode(Metar{FlightRules: tt.rules})
+		if m.FlightRules != tt.rules || m.FlightRulesCategory != tt.want {
+			t.Errorf("decoded %q: FlightRules, FlightRulesCategory = %q, %v, want %q, %v", tt.rules, m.FlightRules, m.FlightRulesCategory, tt.rules, tt.want)
+		}
+
+		var category FlightRulesCategory
+		if text, err := tt.want.MarshalText(); err != nil || category.UnmarshalText(text) != nil || category != tt.want {
+			t.Errorf("%v does not round trip through MarshalText: %q, %v", tt.want, text, err)
+		}
+	}
+}
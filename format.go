@@ -0,0 +1,148 @@
+package avwx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const kmhPerKt = 1.852
+
+// Units selects the units of a report's formatted output.
+type Units int
+
+const (
+	// Imperial formats temperatures in Fahrenheit, wind in knots, visibility in statute miles
+	// and the altimeter in inches of mercury.
+	Imperial Units = iota
+	// Metric formats temperatures in Celsius, wind in km/h, visibility in meters and the
+	// altimeter in hectopascals.
+	Metric
+)
+
+// String summarizes the report on one line in the units selected by the decoder,
+// e.g. "KSFO 221856Z 280° 10 kt, 10 SM, 64°F/45°F, 30.02 inHg".
+func (m *Metar) String() string {
+	var parts []string
+	if wind := m.formatWind(); wind != "" {
+		parts = append(parts, wind)
+	}
+	if visibility := m.formatVisibility(); visibility != "" {
+		parts = append(parts, visibility)
+	}
+	if temperature := m.formatTemperature(m.TemperatureC, m.hasTemperature()); temperature != "" {
+		if dewpoint := m.formatTemperature(m.DewpointC, m.hasDewpoint()); dewpoint != "" {
+			temperature += "/" + dewpoint
+		}
+		parts = append(parts, temperature)
+	}
+	if altimeter := m.formatAltimeter(); altimeter != "" {
+		parts = append(parts, altimeter)
+	}
+
+	header := strings.TrimSpace(m.Station + " " + m.Time)
+	if len(parts) == 0 {
+		return header
+	}
+	return header + " " + strings.Join(parts, ", ")
+}
+
+// Report formats the report as labelled lines in the units selected by the decoder,
+// omitting anything the report does not include.
+func (m *Metar) Report() string {
+	var b strings.Builder
+	line := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%-14s%s\n", label+":", value)
+		}
+	}
+	line("Station", m.Station)
+	line("Time", m.Time)
+	line("Wind", m.formatWind())
+	line("Visibility", m.formatVisibility())
+	line("Temperature", m.formatTemperature(m.TemperatureC, m.hasTemperature()))
+	line("Dewpoint", m.formatTemperature(m.DewpointC, m.hasDewpoint()))
+	line("Altimeter", m.formatAltimeter())
+	line("Flight rules", m.FlightRules)
+	return b.String()
+}
+
+func (m *Metar) formatWind() string {
+	switch {
+	case m.WindDirection == "":
+		return ""
+	case m.IsCalm():
+		return "calm"
+	}
+
+	direction := "variable"
+	if m.WindDirection != "VRB" {
+		direction = fmt.Sprintf("%03d°", m.WindDirectionDeg)
+	}
+	wind := direction + " " + m.formatSpeed(m.WindSpeedKt)
+	if m.WindGustKt > 0 {
+		wind += " gusting " + m.formatSpeed(m.WindGustKt)
+	}
+	return wind
+}
+
+func (m *Metar) formatSpeed(kt int) string {
+	if m.units == Metric {
+		return fmt.Sprintf("%.0f km/h", float64(kt)*kmhPerKt)
+	}
+	return fmt.Sprintf("%d kt", kt)
+}
+
+func (m *Metar) formatVisibility() string {
+	var modifier string
+	switch m.VisibilityModifier {
+	case VisibilityGreaterThan:
+		modifier = "more than "
+	case VisibilityLessThan:
+		modifier = "less than "
+	}
+
+	if m.units == Metric {
+		meters, err := m.VisibilityMeters()
+		if err != nil {
+			return ""
+		}
+		if meters >= 10000 {
+			return modifier + "10 km"
+		}
+		return fmt.Sprintf("%s%d m", modifier, meters)
+	}
+	if !m.hasVisibility() {
+		return ""
+	}
+	return modifier + strconv.FormatFloat(math.Round(m.VisibilitySM*100)/100, 'f', -1, 64) + " SM"
+}
+
+func (m *Metar) formatTemperature(c float64, ok bool) string {
+	if !ok {
+		return ""
+	}
+	if m.units == Metric {
+		return strconv.FormatFloat(c, 'f', 0, 64) + "°C"
+	}
+	return strconv.FormatFloat(cToF(c), 'f', 0, 64) + "°F"
+}
+
+func (m *Metar) formatAltimeter() string {
+	if m.AltimeterInHg == 0 {
+		return ""
+	}
+	if m.units == Metric {
+		return strconv.FormatFloat(m.AltimeterInHg*hPaPerInHg, 'f', 0, 64) + " hPa"
+	}
+	return strconv.FormatFloat(m.AltimeterInHg, 'f', 2, 64) + " inHg"
+}
+
+func (m *Metar) hasTemperature() bool {
+	return m.Temperature != "" || m.TemperatureF != "" || m.TemperatureKelvin != ""
+}
+
+func (m *Metar) hasDewpoint() bool {
+	return m.Dewpoint != "" || m.DewpointF != "" || m.DewpointKelvin != ""
+}
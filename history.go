@@ -0,0 +1,124 @@
+package avwx
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const historyURL = "https://aviationweather.gov/api/data/metar"
+
+// WithHistoryURL sets the archive endpoint used by FetchMetarAt.
+func WithHistoryURL(endpoint string) Option {
+	return func(c *Client) {
+		c.historyURL = endpoint
+	}
+}
+
+// FetchMetarAt fetches the archived METAR observed nearest to when using DefaultClient.
+func FetchMetarAt(station string, when time.Time) *MetarResponse {
+	return DefaultClient.FetchMetarAt(station, when)
+}
+
+// FetchMetarAt fetches the archived METAR observed nearest to when.
+// The avwx API only serves current reports, so the nearest archived observation is
+// queried from the Aviation Weather Center, which keeps roughly the last 15 days,
+// and decoded locally. The response carries both the requested and observed times.
+func (c *Client) FetchMetarAt(station string, when time.Time) *MetarResponse {
+	start := time.Now()
+	metarResp := new(MetarResponse)
+	metarResp.ICAO = station
+	metarResp.RequestedAt = when
+	defer c.finish(metarResp, start)
+
+	// Ask for the two hours surrounding the requested time.
+	end := when.UTC().Add(time.Hour)
+	query := url.Values{}
+	query.Set("ids", station)
+	query.Set("format", "raw")
+	query.Set("hours", "2")
+	query.Set("date", end.Format("2006-01-02T15:04:05Z"))
+
+	resp, err := c.get(context.Background(), station, c.historyURL+"?"+query.Encode())
+	if err != nil {
+		metarResp.Error = newFetchError(station, err)
+		return metarResp
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		metarResp.Error = newFetchError(station, fmt.Errorf("Query failed: %s", resp.Status))
+		return metarResp
+	}
+
+	var nearest *Metar
+	var nearestDiff time.Duration
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		metar, err := c.parseMetar(scanner.Text())
+		if err != nil {
+			continue
+		}
+		observed, err := metar.ObservationTime(end)
+		if err != nil {
+			continue
+		}
+		diff := observed.Sub(when)
+		if diff < 0 {
+			diff = -diff
+		}
+		if nearest == nil || diff < nearestDiff {
+			nearest = metar
+			nearestDiff = diff
+			metarResp.ObservedAt = observed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		metarResp.Error = newFetchError(station, wrapTimeout(err))
+		return metarResp
+	}
+
+	if nearest == nil {
+		metarResp.Error = newFetchError(station, fmt.Errorf("%w near %s", ErrNoReport, when.UTC().Format(time.RFC3339)))
+		return metarResp
+	}
+	metarResp.Metar = *nearest
+	return metarResp
+}
+
+// ObservationTime resolves the day and time of the report, such as "221856Z", into a full UTC time.
+// The report is assumed to be the latest observation at or before ref.
+func (m *Metar) ObservationTime(ref time.Time) (time.Time, error) {
+	value := strings.TrimSuffix(m.Time, "Z")
+	if len(value) != 6 {
+		return time.Time{}, fmt.Errorf("Invalid observation time: %s", m.Time)
+	}
+	day, errDay := strconv.Atoi(value[0:2])
+	hour, errHour := strconv.Atoi(value[2:4])
+	minute, errMinute := strconv.Atoi(value[4:6])
+	if errDay != nil || errHour != nil || errMinute != nil || day < 1 || day > 31 || hour > 23 || minute > 59 {
+		return time.Time{}, fmt.Errorf("Invalid observation time: %s", m.Time)
+	}
+
+	ref = ref.UTC()
+	year, month := ref.Year(), ref.Month()
+	for i := 0; i < 3; i++ {
+		observed := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+		// Skip months without the reported day and reports which would lie in the future.
+		if observed.Day() == day && !observed.After(ref) {
+			return observed, nil
+		}
+		month--
+		if month < time.January {
+			month = time.December
+			year--
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid observation time: %s", m.Time)
+}
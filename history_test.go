@@ -0,0 +1,53 @@
+package avwx
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// historyServer serves two raw reports, observed at and an hour before when.
+func historyServer(when time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "KSFO %s 28010KT 10SM FEW008 M05/M10 A3002\n", when.Format("021504Z"))
+		fmt.Fprintf(w, "KSFO %s 28008KT 10SM FEW008 M06/M10 A3001\n", when.Add(-time.Hour).Format("021504Z"))
+	}))
+}
+
+func TestFetchMetarAt(t *testing.T) {
+	when := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Minute)
+	srv := historyServer(when)
+	defer srv.Close()
+
+	client := NewClient(WithHistoryURL(srv.URL), WithTemperatureUnit(UnitFahrenheit))
+	metarResp := client.FetchMetarAt("KSFO", when.Add(-50*time.Minute))
+	if metarResp.Error != nil {
+		t.Fatalf("FetchMetarAt error: %v", metarResp.Error)
+	}
+	if want := when.Add(-time.Hour); !metarResp.ObservedAt.Equal(want) {
+		t.Errorf("ObservedAt = %v, want %v", metarResp.ObservedAt, want)
+	}
+	// Archived reports are decoded with the client's settings.
+	if m := metarResp.Metar; m.Temperature != "" || m.TemperatureF != "21.2" {
+		t.Errorf("Temperature, TemperatureF = %q, %q, want \"\", \"21.2\"", m.Temperature, m.TemperatureF)
+	}
+}
+
+func TestFetchMetarAtWithoutDecoding(t *testing.T) {
+	when := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Minute)
+	srv := historyServer(when)
+	defer srv.Close()
+
+	metarResp := NewClient(WithHistoryURL(srv.URL), WithoutDecoding()).FetchMetarAt("KSFO", when)
+	if metarResp.Error != nil {
+		t.Fatalf("FetchMetarAt error: %v", metarResp.Error)
+	}
+	if !metarResp.ObservedAt.Equal(when) {
+		t.Errorf("ObservedAt = %v, want %v", metarResp.ObservedAt, when)
+	}
+	if m := metarResp.Metar; m.Temperature != "M05" || m.TemperatureC != 0 || m.CloudLayersDec != nil || m.VisibilitySM != 0 {
+		t.Errorf("FetchMetarAt WithoutDecoding decoded the report: %+v", m)
+	}
+}
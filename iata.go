@@ -0,0 +1,95 @@
+package avwx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// iataCodes maps the IATA codes of airports outside the contiguous US, whose ICAO codes cannot be
+// formed by prefixing a "K", to their ICAO codes.
+var iataCodes = map[string]string{
+	// Alaska and Hawaii
+	"ANC": "PANC",
+	"FAI": "PAFA",
+	"JNU": "PAJN",
+	"HNL": "PHNL",
+	"OGG": "PHOG",
+	"KOA": "PHKO",
+	"LIH": "PHLI",
+	// Canada
+	"YYZ": "CYYZ",
+	"YUL": "CYUL",
+	"YVR": "CYVR",
+	"YYC": "CYYC",
+	"YEG": "CYEG",
+	"YOW": "CYOW",
+	"YWG": "CYWG",
+	"YHZ": "CYHZ",
+	// Mexico and the Caribbean
+	"MEX": "MMMX",
+	"CUN": "MMUN",
+	"GDL": "MMGL",
+	"SJU": "TJSJ",
+	// Europe
+	"LHR": "EGLL",
+	"LGW": "EGKK",
+	"MAN": "EGCC",
+	"DUB": "EIDW",
+	"CDG": "LFPG",
+	"ORY": "LFPO",
+	"AMS": "EHAM",
+	"BRU": "EBBR",
+	"FRA": "EDDF",
+	"MUC": "EDDM",
+	"BER": "EDDB",
+	"ZRH": "LSZH",
+	"GVA": "LSGG",
+	"VIE": "LOWW",
+	"MAD": "LEMD",
+	"BCN": "LEBL",
+	"LIS": "LPPT",
+	"FCO": "LIRF",
+	"MXP": "LIMC",
+	"CPH": "EKCH",
+	"ARN": "ESSA",
+	"OSL": "ENGM",
+	"HEL": "EFHK",
+	"KEF": "BIKF",
+	"IST": "LTFM",
+	// Asia and Oceania
+	"DXB": "OMDB",
+	"DOH": "OTHH",
+	"DEL": "VIDP",
+	"BOM": "VABB",
+	"SIN": "WSSS",
+	"HKG": "VHHH",
+	"PEK": "ZBAA",
+	"PVG": "ZSPD",
+	"NRT": "RJAA",
+	"HND": "RJTT",
+	"ICN": "RKSI",
+	"SYD": "YSSY",
+	"MEL": "YMML",
+	"AKL": "NZAA",
+	// South America and Africa
+	"GRU": "SBGR",
+	"EZE": "SAEZ",
+	"BOG": "SKBO",
+	"SCL": "SCEL",
+	"JNB": "FAOR",
+	"CAI": "HECA",
+}
+
+// IATAtoICAO converts a 3 letter IATA airport code to its ICAO code. Airports outside the contiguous
+// US are looked up in a table of major airports; other codes are assumed to be US airports and are
+// prefixed with a "K". Use ResolveICAO to look up any airport through the API instead.
+func IATAtoICAO(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return code, fmt.Errorf("Invalid IATA code: %s", code)
+	}
+	if icao, ok := iataCodes[code]; ok {
+		return icao, nil
+	}
+	return "K" + code, nil
+}
@@ -0,0 +1,14 @@
+package avwx
+
+import "encoding/json"
+
+// CanonicalJSON encodes the report in the package's documented JSON shape: every exported field in
+// declaration order under its JSON name, indented by two spaces and ending in a newline. The output of
+// equal reports is byte for byte identical, so it can be stored as a golden file and compared.
+func (m *Metar) CanonicalJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
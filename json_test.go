@@ -0,0 +1,47 @@
+package avwx
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		golden string
+		raw    string
+	}{
+		{"us.golden", "KSFO 221756Z 28010G18KT 10SM -RA BR FEW008 BKN200 18/12 A3002 RMK AO2 PK WND 28045/1955 SLP167 P0012 T01830122"},
+		{"icao.golden", "EGLL 221750Z 24015KT CAVOK 12/06 Q1013 NOSIG"},
+		{"missing.golden", "KXYZ 221756Z AUTO 28010KT 10SM ////// ///// A3002"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		got, err := m.CanonicalJSON()
+		if err != nil {
+			t.Fatalf("%s: CanonicalJSON error: %v", tt.golden, err)
+		}
+
+		path := filepath.Join("testdata", tt.golden)
+		if *update {
+			if err := os.WriteFile(path, got, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: CanonicalJSON changed; run go test -update if intended\n got: %s\nwant: %s", tt.golden, got, want)
+		}
+	}
+}
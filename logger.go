@@ -0,0 +1,44 @@
+package avwx
+
+import "time"
+
+// FetchEvent describes a single request made to the API.
+type FetchEvent struct {
+	Station    string
+	URL        string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Logger receives an event for every request made by a Client.
+// It may be called from multiple goroutines at once.
+type Logger interface {
+	LogFetch(event FetchEvent)
+}
+
+// LoggerFunc adapts an ordinary function to the Logger interface.
+type LoggerFunc func(event FetchEvent)
+
+// LogFetch calls f(event).
+func (f LoggerFunc) LogFetch(event FetchEvent) {
+	f(event)
+}
+
+// WithLogger sets the Logger notified of each request. No logging is done by default.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// MetricsHook is called after each fetch completes with the station, how long the fetch took
+// including decoding, and the resulting error if any. It may be called from multiple goroutines at once.
+type MetricsHook func(station string, duration time.Duration, err error)
+
+// WithMetricsHook sets the hook called after each fetch, for example to update counters and histograms.
+func WithMetricsHook(hook MetricsHook) Option {
+	return func(c *Client) {
+		c.metricsHook = hook
+	}
+}
@@ -0,0 +1,79 @@
+package avwx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/KXXX") {
+			http.NotFound(w, r)
+			return
+		}
+		metarHandler(w, r)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		station string
+		wantErr error
+	}{
+		{"KSFO", nil},
+		{"KXXX", ErrStationNotFound},
+	}
+	for _, tt := range tests {
+		var (
+			calls    int
+			station  string
+			duration time.Duration
+			hookErr  error
+		)
+		client := NewClient(WithBaseURL(srv.URL+"/"), WithMetricsHook(func(s string, d time.Duration, err error) {
+			calls++
+			station, duration, hookErr = s, d, err
+		}))
+		start := time.Now()
+		metarResp := client.FetchMetar(tt.station)
+		elapsed := time.Since(start)
+
+		if calls != 1 {
+			t.Fatalf("%s: metrics hook called %d times, want 1", tt.station, calls)
+		}
+		if station != tt.station {
+			t.Errorf("%s: hook station = %q", tt.station, station)
+		}
+		if duration <= 0 || duration > elapsed || duration != metarResp.Duration {
+			t.Errorf("%s: hook duration = %v, want positive, at most %v and equal to %v", tt.station, duration, elapsed, metarResp.Duration)
+		}
+		if hookErr != metarResp.Error {
+			t.Errorf("%s: hook error = %v, response error = %v", tt.station, hookErr, metarResp.Error)
+		}
+		if tt.wantErr == nil && hookErr != nil || tt.wantErr != nil && !errors.Is(hookErr, tt.wantErr) {
+			t.Errorf("%s: hook error = %v, want %v", tt.station, hookErr, tt.wantErr)
+		}
+	}
+}
+
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer srv.Close()
+
+	var events []FetchEvent
+	client := NewClient(WithBaseURL(srv.URL+"/"), WithLogger(LoggerFunc(func(event FetchEvent) {
+		events = append(events, event)
+	})))
+	if metarResp := client.FetchMetar("KSFO"); metarResp.Error != nil {
+		t.Fatalf("FetchMetar error: %v", metarResp.Error)
+	}
+	if len(events) != 1 {
+		t.Fatalf("logger received %d events, want 1", len(events))
+	}
+	if e := events[0]; e.Station != "KSFO" || e.StatusCode != http.StatusOK || e.Err != nil || !strings.HasPrefix(e.URL, srv.URL) {
+		t.Errorf("logged event %+v", e)
+	}
+}
@@ -0,0 +1,45 @@
+package avwx
+
+import (
+	"strings"
+	"time"
+)
+
+// Merge returns whichever of the two reports should be kept for a station: update when it supersedes
+// current, otherwise current. An older report arriving after a newer one never replaces it.
+func Merge(current, update *Metar) *Metar {
+	if update != nil && update.Supersedes(current) {
+		return update
+	}
+	return current
+}
+
+// Supersedes reports whether the report should replace prev, a previously received report for the
+// same station. A report supersedes one observed earlier, and a correction supersedes the original
+// observation or an earlier correction of it.
+func (m *Metar) Supersedes(prev *Metar) bool {
+	if prev == nil {
+		return true
+	}
+	if !strings.EqualFold(m.Station, prev.Station) {
+		return false
+	}
+
+	now := time.Now()
+	observed, err := m.ObservationTime(now)
+	if err != nil {
+		return false
+	}
+	prevObserved, err := prev.ObservationTime(now)
+	if err != nil {
+		return true
+	}
+
+	switch {
+	case observed.After(prevObserved):
+		return true
+	case observed.Equal(prevObserved):
+		return m.Corrected && (!prev.Corrected || m.CorrectionSeq >= prev.CorrectionSeq)
+	}
+	return false
+}
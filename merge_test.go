@@ -0,0 +1,49 @@
+package avwx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	// Observations from yesterday resolve to the same day whenever the test runs.
+	day := time.Now().UTC().AddDate(0, 0, -1).Day()
+	report := func(station, hhmm, correction string) *Metar {
+		m := &Metar{Station: station, Time: fmt.Sprintf("%02d%sZ", day, hhmm)}
+		m.RawReport = station + " " + m.Time
+		if correction != "" {
+			m.RawReport += " " + correction
+		}
+		new(Decoder).decodeMetar(m)
+		return m
+	}
+	original := report("KSFO", "1756", "")
+	later := report("KSFO", "1856", "")
+	cor := report("KSFO", "1756", "COR")
+	cca := report("KSFO", "1756", "CCA")
+	ccb := report("KSFO", "1756", "CCB")
+	other := report("KOAK", "1856", "")
+
+	tests := []struct {
+		name            string
+		current, update *Metar
+		want            *Metar
+	}{
+		{"first report", nil, original, original},
+		{"later report", original, later, later},
+		{"out of order", later, original, later},
+		{"correction", original, cor, cor},
+		{"original after its correction", cor, original, cor},
+		{"later correction", cca, ccb, ccb},
+		{"earlier correction", ccb, cca, ccb},
+		{"correction of an older observation", later, cca, later},
+		{"other station", original, other, original},
+		{"nil update", original, nil, original},
+	}
+	for _, tt := range tests {
+		if got := Merge(tt.current, tt.update); got != tt.want {
+			t.Errorf("%s: Merge kept %q, want %q", tt.name, got.RawReport, tt.want.RawReport)
+		}
+	}
+}
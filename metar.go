@@ -3,54 +3,67 @@ package avwx
 import (
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	baseURL = "https://avwx.rest/api/metar/"
-	options = "?options=info"
+	baseURL        = "https://avwx.rest/api/metar/"
+	defaultOptions = "info,summary,speech"
+
+	hPaPerInHg = 33.8639
 )
 
 var conditions = map[string]string{
-	"RA":   "RAIN",
-	"DZ":   "DRIZZLE",
-	"SN":   "SNOW",
-	"SG":   "SNOW GRAINS",
-	"IC":   "ICE CRYSTALS",
-	"PL":   "ICE PELLETS",
-	"GR":   "HAIL",
-	"GS":   "SMALL HAIL/SNOW PELLETS",
-	"UP":   "UNKNOWN PRECIPITATION",
-	"BR":   "MIST",
-	"FG":   "FOG",
-	"FU":   "SMOKE",
-	"VA":   "VOLCANIC ASH",
-	"SA":   "SAND",
-	"HZ":   "HAZE",
-	"PY":   "SPRAY",
-	"DU":   "DUST",
-	"SQ":   "SQUALL",
-	"SS":   "SANDSTORM",
-	"DS":   "DUSTSTORM",
-	"PO":   "WELL DEVELOPED DUST/SAND WHIRLS",
-	"FC":   "FUNNEL CLOUD",
-	"VC":   "IN VICINITY",
-	"MI":   "SHALLOW",
-	"BC":   "PATCHES",
-	"SH":   "SHOWERS",
-	"PR":   "PARTIAL",
-	"TS":   "THUNDERSTORM",
-	"TSRA": "THUNDERSTORM/HEAVY RAIN",
-	"BL":   "BLOWING",
-	"DR":   "DRIFTING",
-	"FZ":   "FREEZING",
+	"RA": "RAIN",
+	"DZ": "DRIZZLE",
+	"SN": "SNOW",
+	"SG": "SNOW GRAINS",
+	"IC": "ICE CRYSTALS",
+	"PL": "ICE PELLETS",
+	"GR": "HAIL",
+	"GS": "SMALL HAIL/SNOW PELLETS",
+	"UP": "UNKNOWN PRECIPITATION",
+	"BR": "MIST",
+	"FG": "FOG",
+	"FU": "SMOKE",
+	"VA": "VOLCANIC ASH",
+	"SA": "SAND",
+	"HZ": "HAZE",
+	"PY": "SPRAY",
+	"DU": "DUST",
+	"SQ": "SQUALL",
+	"SS": "SANDSTORM",
+	"DS": "DUSTSTORM",
+	"PO": "WELL DEVELOPED DUST/SAND WHIRLS",
+	"FC": "FUNNEL CLOUD",
+	"VC": "IN VICINITY",
+	"MI": "SHALLOW",
+	"BC": "PATCHES",
+	"SH": "SHOWERS",
+	"PR": "PARTIAL",
+	"TS": "THUNDERSTORM",
+	"BL": "BLOWING",
+	"DR": "DRIFTING",
+	"FZ": "FREEZING",
+}
+
+var descriptors = map[string]bool{
+	"MI": true,
+	"PR": true,
+	"BC": true,
+	"DR": true,
+	"BL": true,
+	"SH": true,
+	"TS": true,
+	"FZ": true,
 }
 
 var coverage = map[string]string{
 	"FEW": "FEW",
 	"SKC": "SKY CLEAR",
+	"CLR": "CLEAR BELOW 12000",
 	"OVC": "OVERCAST",
 	"SCT": "SCATTERED",
 	"BKN": "BROKEN",
@@ -64,132 +77,284 @@ var cloudTypes = map[string]string{
 }
 
 // FetchMetar fetches the current METAR for given station represented by a valid ICAO airport code.
+// It uses DefaultClient.
 func FetchMetar(station string) *MetarResponse {
-	//start := time.Now()
-	url := baseURL + station + options
+	return DefaultClient.FetchMetar(station)
+}
 
-	metarResp := new(MetarResponse)
-	metarResp.ICAO = station
+// FetchMetarOptions fetches the current METAR for the given station using DefaultClient,
+// requesting the given API options such as "info", "translate", "summary" or "speech".
+func FetchMetarOptions(station string, opts ...string) *MetarResponse {
+	return DefaultClient.FetchMetarOptions(station, opts...)
+}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		metarResp.Error = err
-		return metarResp
-	}
+// FetchMetarDirect fetches the current METAR for the given station using DefaultClient,
+// returning the report and any error instead of a MetarResponse.
+func FetchMetarDirect(station string) (*Metar, error) {
+	return DefaultClient.FetchMetarDirect(station)
+}
 
-	defer resp.Body.Close()
+func (d *Decoder) decodeMetar(metar *Metar) {
+	groups := reportGroups(metar.RawReport)
+	metar.units = d.Units
 
-	if resp.StatusCode != http.StatusOK {
-		metarResp.Error = fmt.Errorf("Query failed: %s", resp.Status)
-		return metarResp
+	switch altimeter, err := strconv.ParseFloat(metar.Altimeter, 64); {
+	case isMissing(metar.Altimeter):
+		metar.Altimeter = ""
+	case err != nil:
+		metar.addDecodeError("altimeter", metar.Altimeter)
+		metar.Altimeter = ""
+	case isHpaAltimeter(groups, altimeter):
+		metar.AltimeterInHg = altimeter / hPaPerInHg
+		metar.AltimeterHpa = strconv.FormatFloat(altimeter, 'f', 0, 64)
+		metar.Altimeter = strconv.FormatFloat(altimeter/hPaPerInHg, 'f', precision(d.AltimeterPrecision, 2), 64)
+	default:
+		metar.AltimeterInHg = altimeter / 100
+		metar.AltimeterHpa = strconv.FormatFloat(altimeter/100*hPaPerInHg, 'f', 0, 64)
+		metar.Altimeter = strconv.FormatFloat(altimeter/100, 'f', precision(d.AltimeterPrecision, 2), 64)
 	}
+	metar.Altimeters = decodeAltimeters(groups)
+	metar.reconcileAltimeters()
 
-	var metar Metar
-	if err := json.NewDecoder(resp.Body).Decode(&metar); err != nil {
-		metarResp.Error = err
-		return metarResp
+	if isMissing(metar.Temperature) {
+		metar.Temperature = ""
+		metar.TemperatureMissing = true
+	} else if temp, err := parseSigned(metar.Temperature); err != nil {
+		metar.addDecodeError("temperature", metar.Temperature)
+		metar.Temperature = ""
+	} else {
+		metar.TemperatureC = temp
+		metar.Temperature, metar.TemperatureF, metar.TemperatureKelvin = d.formatTemperature(temp)
 	}
-	decodeMetar(&metar)
-	metarResp.Metar = metar
-	//fmt.Printf("\nFetched: %s in %.2fs\n", station, time.Since(start).Seconds())
-	return metarResp
-}
 
-func decodeMetar(metar *Metar) {
-
-	altimeter, _ := strconv.ParseFloat(metar.Altimeter, 64)
-	metar.Altimeter = strconv.FormatFloat(altimeter/100, 'f', 2, 64)
-
-	metar.Temperature = strings.Replace(metar.Temperature, "M", "-", 1)
-	temp, _ := strconv.ParseFloat(metar.Temperature, 64)
-	metar.TemperatureF = fmt.Sprintf("%.1f", cToF(temp))
-	metar.Temperature = fmt.Sprintf("%.1f", temp)
+	if isMissing(metar.Dewpoint) {
+		metar.Dewpoint = ""
+		metar.DewpointMissing = true
+	} else if dewpoint, err := parseSigned(metar.Dewpoint); err != nil {
+		metar.addDecodeError("dewpoint", metar.Dewpoint)
+		metar.Dewpoint = ""
+	} else {
+		metar.DewpointC = dewpoint
+		metar.Dewpoint, metar.DewpointF, metar.DewpointKelvin = d.formatTemperature(dewpoint)
+	}
 
-	metar.Dewpoint = strings.Replace(metar.Dewpoint, "M", "-", 1)
-	dewpoint, _ := strconv.ParseFloat(metar.Dewpoint, 64)
-	metar.DewpointF = fmt.Sprintf("%.1f", cToF(dewpoint))
-	metar.Dewpoint = fmt.Sprintf("%.1f", dewpoint)
+	visibility := metar.Visibility
+	if strings.HasPrefix(visibility, "P") {
+		metar.VisibilityModifier = VisibilityGreaterThan
+		visibility = visibility[1:]
+	} else if rest, less := cutMinus(visibility); less {
+		metar.VisibilityModifier = VisibilityLessThan
+		visibility = rest
+	}
+	if visibilitySM, err := parseVisibility(visibility); err == nil {
+		metar.VisibilitySM = visibilitySM
+		if metar.VisibilityModifier == "" {
+			metar.VisibilityModifier = VisibilityExact
+		}
+	} else if !isMissing(visibility) {
+		metar.addDecodeError("visibility", metar.Visibility)
+	}
+	if meters, err := metar.VisibilityMeters(); err == nil {
+		// Meters are given as four digits, as in reports from outside the US, with 9999 for 10 km or more.
+		if meters > 9999 {
+			meters = 9999
+		}
+		metar.VisibilityM = fmt.Sprintf("%04d", meters)
+	}
 
-	windDegrees, _ := strconv.ParseInt(metar.WindDirection, 10, 32)
-	metar.WindDirectionDesc = GetDirectionDesc(windDegrees)
+	switch {
+	case metar.IsCalm():
+		// 00000KT carries no direction, so it is not decoded as a northerly wind.
+		metar.Calm = true
+		metar.WindDirectionDesc = "CALM"
+	case metar.WindDirection == "VRB":
+		metar.WindDirectionDesc = "VARIABLE"
+	case metar.WindDirection != "":
+		if windDegrees, err := strconv.ParseInt(metar.WindDirection, 10, 32); err == nil {
+			metar.WindDirectionDeg = int(windDegrees)
+			metar.WindDirectionDesc = GetDirectionDesc(windDegrees)
+		} else {
+			metar.addDecodeError("wind direction", metar.WindDirection)
+		}
+	}
 
+	if len(metar.Conditions) > 0 {
+		metar.ConditionsDec = make([]ConditionDec, 0, len(metar.Conditions))
+	}
 	for _, condition := range metar.Conditions {
-		modifier := ""
-		vicinity := false
+		metar.ConditionsDec = append(metar.ConditionsDec, decodeCondition(condition))
+	}
 
-		if strings.HasPrefix(condition, "VC") {
-			vicinity = true
-			condition = condition[2:]
+	if metar.WindSpeed != "" {
+		if speed, err := strconv.Atoi(metar.WindSpeed); err == nil {
+			metar.WindSpeedKt = speed
+			metar.WindSpeedKmh = formatKmh(speed)
+		} else {
+			metar.addDecodeError("wind speed", metar.WindSpeed)
 		}
-		if strings.HasPrefix(condition, "-") {
-			modifier = "LIGHT"
-			condition = condition[1:]
-		} else if strings.HasPrefix(condition, "+") {
-			modifier = "HEAVY"
-			condition = condition[1:]
+	}
+	if metar.WindGust != "" {
+		if gust, err := strconv.Atoi(metar.WindGust); err == nil {
+			metar.WindGustKt = gust
+			metar.WindGustKmh = formatKmh(gust)
+		} else {
+			metar.addDecodeError("wind gust", metar.WindGust)
 		}
+	}
 
-		conditionDec := new(ConditionDec)
-		conditionDec.Desc = conditions[condition]
-		conditionDec.Modifier = modifier
-		if vicinity {
-			conditionDec.Other = "IN VICINITY"
+	for _, group := range groups {
+		if group == "COR" {
+			metar.Corrected = true
+		} else if len(group) == 3 && strings.HasPrefix(group, "CC") && group[2] >= 'A' && group[2] <= 'Z' {
+			metar.Corrected = true
+			metar.CorrectionSeq = group[2:]
+		}
+		if len(group) > 2 && strings.HasPrefix(group, "RE") && isWeatherGroup(group[2:]) {
+			metar.RecentWeather = append(metar.RecentWeather, decodeCondition(group[2:]))
 		}
-		metar.ConditionsDec = append(metar.ConditionsDec, *conditionDec)
 	}
 
+	if len(metar.CloudLayers) > 0 {
+		metar.CloudLayersDec = make([]CloudLayerDec, 0, len(metar.CloudLayers))
+	}
 	for _, layer := range metar.CloudLayers {
-		cloudLayerDec := new(CloudLayerDec)
-		cloudLayerDec.Coverage = coverage[layer[0]]
-		height, _ := strconv.ParseInt(layer[1], 10, 64)
-		cloudLayerDec.HeightFt = fmt.Sprintf("%d", height*100)
-		if len(layer) > 2 {
-			cloudLayerDec.Type = cloudTypes[layer[2]]
+		if len(layer) == 0 {
+			continue
+		}
+		if len(layer) > 1 && !isMissing(layer[1]) && !isDigits(layer[1]) {
+			metar.addDecodeError("cloud height", layer[1])
+		}
+		metar.CloudLayersDec = append(metar.CloudLayersDec, decodeCloudLayer(layer))
+	}
+
+	if metar.FlightRules == "" {
+		// Reports parsed locally carry no flight rules from the API.
+		metar.FlightRules = metar.computeFlightRules()
+	}
+	metar.FlightRulesCategory = ParseFlightRules(metar.FlightRules)
+
+	metar.WindShear = decodeWindShear(groups)
+	metar.SecondaryVisibility = decodeSecondaryVisibility(groups, metar.Remarks)
+	metar.RemarksDec = decodeRemarks(metar.Remarks)
+	// The T group gives the temperature and dewpoint to a tenth of a degree. Only the Celsius values
+	// take the precision; the strings keep the whole degrees of the body.
+	if temp := metar.RemarksDec.Temperature; temp != nil {
+		metar.TemperatureC = *temp
+	}
+	if dewpoint := metar.RemarksDec.Dewpoint; dewpoint != nil {
+		metar.DewpointC = *dewpoint
+	}
+	// A trailing "$" marks a station in need of maintenance whose data may be suspect.
+	metar.MaintenanceNeeded = strings.HasSuffix(strings.TrimSpace(metar.RawReport), "$")
+
+	metar.Trends = decodeTrends(groups)
+	for _, trend := range metar.Trends {
+		if trend.Type == "NOSIG" {
+			metar.NoSignificantChange = true
 		}
-		metar.CloudLayersDec = append(metar.CloudLayersDec, *cloudLayerDec)
 	}
 }
 
-func GetDirectionDesc(degrees int64) string {
-	switch {
-	case (degrees > 349 && degrees <= 360) || (degrees >= 0 && degrees <= 11):
-		return "N"
-	case degrees > 11 && degrees <= 34:
-		return "NNE"
-	case degrees > 34 && degrees <= 56:
-		return "NE"
-	case degrees > 56 && degrees <= 79:
-		return "ENE"
-	case degrees > 79 && degrees <= 101:
-		return "E"
-	case degrees > 101 && degrees <= 124:
-		return "ESE"
-	case degrees > 124 && degrees <= 146:
-		return "SE"
-	case degrees > 146 && degrees <= 169:
-		return "SSE"
-	case degrees > 169 && degrees <= 191:
-		return "S"
-	case degrees > 191 && degrees <= 214:
-		return "SSW"
-	case degrees > 214 && degrees <= 236:
-		return "SW"
-	case degrees > 236 && degrees <= 259:
-		return "WSW"
-	case degrees > 259 && degrees <= 281:
-		return "W"
-	case degrees > 281 && degrees <= 304:
-		return "WNW"
-	case degrees > 304 && degrees <= 326:
-		return "NW"
-	case degrees > 326 && degrees <= 349:
-		return "NNW"
-	default:
-		return ""
+// decodeCloudLayer decodes a non-empty cloud layer. Layers missing their height, as the API may send
+// for sky clear, are decoded with an empty HeightFt.
+func decodeCloudLayer(layer []string) CloudLayerDec {
+	var cloudLayerDec CloudLayerDec
+	cloudLayerDec.Coverage, _ = LookupCoverage(layer[0])
+	if len(layer) > 1 {
+		if height, err := strconv.ParseInt(layer[1], 10, 64); err == nil {
+			cloudLayerDec.HeightFtInt = int(height * 100)
+			cloudLayerDec.HeightFt = strconv.Itoa(cloudLayerDec.HeightFtInt)
+		}
+	}
+	if len(layer) > 2 {
+		cloudLayerDec.Type, _ = LookupCloudType(layer[2])
+	}
+	return cloudLayerDec
+}
+
+func decodeCondition(condition string) ConditionDec {
+	modifier := ""
+	descriptor := ""
+	vicinity := false
+
+	if strings.HasPrefix(condition, "VC") {
+		vicinity = true
+		condition = condition[2:]
+	}
+	if strings.HasPrefix(condition, "-") {
+		modifier = "LIGHT"
+		condition = condition[1:]
+	} else if strings.HasPrefix(condition, "+") {
+		modifier = "HEAVY"
+		condition = condition[1:]
+	}
+	if len(condition) > 2 && descriptors[condition[:2]] {
+		descriptor, _ = LookupCondition(condition[:2])
+		condition = condition[2:]
+	}
+
+	var conditionDec ConditionDec
+	conditionDec.Desc, _ = LookupCondition(condition)
+	if conditionDec.Desc == "" {
+		// Multiple phenomena may be combined in one group, e.g. RASN.
+		var descs []string
+		for _, code := range conditionCodes(condition) {
+			if desc, ok := LookupCondition(code); ok {
+				descs = append(descs, desc)
+			}
+		}
+		conditionDec.Desc = strings.Join(descs, "/")
+	}
+	conditionDec.Modifier = modifier
+	conditionDec.Descriptor = descriptor
+	if vicinity {
+		conditionDec.Other = "IN VICINITY"
+	}
+	return conditionDec
+}
+
+// directionDescs holds the compass description of each whole degree, so GetDirectionDesc is a single lookup.
+var directionDescs = func() (descs [360]string) {
+	// Each point covers the degrees above the previous point's upper bound up to its own.
+	points := []struct {
+		upper int
+		desc  string
+	}{
+		{11, "N"}, {34, "NNE"}, {56, "NE"}, {79, "ENE"},
+		{101, "E"}, {124, "ESE"}, {146, "SE"}, {169, "SSE"},
+		{191, "S"}, {214, "SSW"}, {236, "SW"}, {259, "WSW"},
+		{281, "W"}, {304, "WNW"}, {326, "NW"}, {349, "NNW"},
+		{359, "N"},
 	}
+	degree := 0
+	for _, point := range points {
+		for ; degree <= point.upper; degree++ {
+			descs[degree] = point.desc
+		}
+	}
+	return descs
+}()
+
+// GetDirectionDesc returns the 16-point compass description, such as "NNE", for a direction in degrees.
+// Values outside 0-360 wrap around, so -45 is NW and 405 is NE.
+func GetDirectionDesc(degrees int64) string {
+	return directionDescs[(degrees%360+360)%360]
 }
 
+// FormatICAO upper-cases an airport code, converting 3 character codes with IATAtoICAO. Codes missing
+// from its table are assumed to be contiguous US airports and prefixed with a "K"; use FormatICAOExact
+// or ResolveICAO for airports elsewhere.
 func FormatICAO(icao string) (string, error) {
+	return formatICAO(icao, true)
+}
+
+// FormatICAOExact upper-cases an airport code like FormatICAO, but leaves 3 character codes as-is
+// rather than assuming they are US airports.
+func FormatICAOExact(icao string) (string, error) {
+	return formatICAO(icao, false)
+}
+
+func formatICAO(icao string, prefixUS bool) (string, error) {
 	len := len(icao)
 
 	if len < 3 || len > 4 {
@@ -197,62 +362,211 @@ func FormatICAO(icao string) (string, error) {
 	}
 
 	icao = strings.ToUpper(icao)
-	if len < 4 {
-		icao = "K" + icao
+	if len < 4 && prefixUS {
+		return IATAtoICAO(icao)
 	}
 
 	return icao, nil
 }
 
+// addDecodeError records a value which could not be decoded. The field is left blank rather than
+// reporting a fabricated zero.
+func (m *Metar) addDecodeError(field, value string) {
+	m.DecodeErrors = append(m.DecodeErrors, fmt.Errorf("Invalid %s: %q", field, value))
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// cutMinus removes the leading "M" which marks a negative or less-than value, as in "M05" or "M1/4",
+// reporting whether it was present.
+func cutMinus(value string) (string, bool) {
+	if strings.HasPrefix(value, "M") {
+		return value[1:], true
+	}
+	return value, false
+}
+
+// parseSigned parses a numeric value in which a leading "M" marks a negative number. Only plain
+// decimal numbers are accepted, so malformed values such as "MM05", "M-5" or "1e3" are rejected.
+func parseSigned(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	number, minus := cutMinus(value)
+	digits := number
+	if !minus {
+		digits = strings.TrimPrefix(digits, "-")
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if !isDigits(whole) || fraction != "" && !isDigits(fraction) {
+		return 0, fmt.Errorf("Invalid number: %q", value)
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if minus {
+		n = -n
+	}
+	return n, err
+}
+
+// isMissing reports whether a raw value was omitted, either empty or reported as slashes. A value
+// which merely contains a slash, such as a fraction, is not missing.
+func isMissing(value string) bool {
+	return strings.Trim(strings.TrimSpace(value), "/") == ""
+}
+
+// isHpaAltimeter reports whether the altimeter was given in hectopascals as a Q group rather than
+// in hundredths of inches of mercury as an A group. The group carrying the value decides, so a report
+// with both is read correctly. Without a matching group the magnitude decides.
+func isHpaAltimeter(groups []string, altimeter float64) bool {
+	for _, group := range groups {
+		if !altimeterPattern.MatchString(group) {
+			continue
+		}
+		if value, _ := strconv.ParseFloat(group[1:], 64); value == altimeter {
+			return group[0] == 'Q'
+		}
+	}
+	return altimeter < 2000
+}
+
+// formatKmh formats a wind speed in knots as whole km/h.
+func formatKmh(kt int) string {
+	return strconv.FormatFloat(float64(kt)*kmhPerKt, 'f', 0, 64)
+}
+
 func cToF(c float64) float64 {
 	return c*9/5 + 32
 }
 
+func cToK(c float64) float64 {
+	return c + 273.15
+}
+
 type Metar struct {
-	Altimeter         string
-	Dewpoint          string
-	DewpointF         string
-	FlightRules       string `json:"Flight-Rules"`
-	RawReport         string `json:"Raw-Report"`
-	Remarks           string
-	Station           string
-	Temperature       string
-	TemperatureF      string
-	Time              string
-	Visibility        string
-	WindDirection     string `json:"Wind-Direction"`
-	WindDirectionDesc string
-	WindGust          string     `json:"Wind-Gust"`
-	WindSpeed         string     `json:"Wind-Speed"`
-	CloudLayers       [][]string `json:"Cloud-List"`
-	CloudLayersDec    []CloudLayerDec
-	Conditions        []string `json:"Other-List"`
-	ConditionsDec     []ConditionDec
-	Error             string
-	LocationInfo      LocationInfo `json:"Info"`
+	Altimeter           string
+	AltimeterHpa        string
+	AltimeterInHg       float64
+	Altimeters          []AltimeterDec
+	Dewpoint            string
+	DewpointF           string
+	DewpointC           float64
+	DewpointKelvin      string
+	DewpointMissing     bool
+	FlightRules         string `json:"Flight-Rules"`
+	FlightRulesCategory FlightRulesCategory
+	RawReport           string `json:"Raw-Report"`
+	Remarks             string
+	RemarksDec          RemarksDec
+	Speech              string
+	Station             string
+	Summary             string
+	Translations        Translations
+	Temperature         string
+	TemperatureF        string
+	TemperatureC        float64
+	TemperatureKelvin   string
+	TemperatureMissing  bool
+	Time                string
+	Visibility          string
+	VisibilityModifier  string
+	VisibilitySM        float64
+	VisibilityM         string
+	SecondaryVisibility []SecondaryVisibilityDec
+	WindDirection       string `json:"Wind-Direction"`
+	WindDirectionDesc   string
+	WindDirectionDeg    int
+	WindGust            string `json:"Wind-Gust"`
+	WindGustKt          int
+	WindGustKmh         string
+	WindSpeed           string `json:"Wind-Speed"`
+	WindSpeedKt         int
+	WindSpeedKmh        string
+	Calm                bool
+	CloudLayers         [][]string `json:"Cloud-List"`
+	CloudLayersDec      []CloudLayerDec
+	Conditions          []string `json:"Other-List"`
+	ConditionsDec       []ConditionDec
+	RecentWeather       []ConditionDec
+	WindShear           []string
+	Trends              []TrendDec
+	NoSignificantChange bool
+	Error               string
+	Corrected           bool
+	CorrectionSeq       string
+	MaintenanceNeeded   bool
+	DecodeErrors        []error      `json:"-"`
+	LocationInfo        LocationInfo `json:"Info"`
+
+	// units selects the units of String and Report, as set by the decoder.
+	units Units
 }
 
 type LocationInfo struct {
-	City    string
-	Country string
-	Name    string
-	State   string
+	City        string
+	Country     string
+	Name        string
+	State       string
+	Latitude    float64
+	Longitude   float64
+	ElevationFt float64
+}
+
+// UnmarshalJSON decodes the location info, accepting coordinates given either as numbers or as
+// numeric strings. Values which are neither are left zero rather than failing the whole report.
+func (l *LocationInfo) UnmarshalJSON(data []byte) error {
+	type plain LocationInfo
+	var info struct {
+		plain
+		Latitude    json.RawMessage
+		Longitude   json.RawMessage
+		ElevationFt json.RawMessage
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return err
+	}
+	*l = LocationInfo(info.plain)
+	l.Latitude = jsonFloat(info.Latitude)
+	l.Longitude = jsonFloat(info.Longitude)
+	l.ElevationFt = jsonFloat(info.ElevationFt)
+	return nil
+}
+
+func jsonFloat(data json.RawMessage) float64 {
+	value, _ := strconv.ParseFloat(strings.Trim(string(data), `"`), 64)
+	return value
 }
 
 type ConditionDec struct {
-	Modifier string
-	Desc     string
-	Other    string
+	Modifier   string
+	Descriptor string
+	Desc       string
+	Other      string
 }
 
 type CloudLayerDec struct {
-	Coverage string
-	HeightFt string
-	Type     string
+	Coverage    string
+	HeightFt    string
+	HeightFtInt int
+	Type        string
 }
 
 type MetarResponse struct {
-	Metar Metar
-	Error error
-	ICAO  string
+	Metar       Metar
+	Error       error
+	ICAO        string
+	RequestedAt time.Time
+	ObservedAt  time.Time
+	Duration    time.Duration
+	// LocationError is set when WithStationCoordinates is in use and the station coordinates could
+	// not be looked up. The report is still returned, without coordinates.
+	LocationError error
+	// RawJSON is the response body as received from the API, before decoding. It is only kept by
+	// clients created with WithRawJSON.
+	RawJSON []byte `json:"-"`
 }
@@ -0,0 +1,407 @@
+package avwx
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// decode runs the default decoder over a report as delivered by the API.
+func decode(metar Metar) *Metar {
+	new(Decoder).decodeMetar(&metar)
+	return &metar
+}
+
+func TestDecodeMissingTemperature(t *testing.T) {
+	tests := []struct {
+		temperature, dewpoint   string
+		tempMissing, dewMissing bool
+		tempC, dewpointC        float64
+		tempStr, tempF          string
+	}{
+		{"/////", "/////", true, true, 0, 0, "", ""},
+		{"//", "M05", true, false, 0, -5, "", ""},
+		{"M05", "M10", false, false, -5, -10, "-5.0", "23.0"},
+		{"05", "", false, true, 5, 0, "5.0", "41.0"},
+	}
+	for _, tt := range tests {
+		m := decode(Metar{Temperature: tt.temperature, Dewpoint: tt.dewpoint})
+		if m.TemperatureMissing != tt.tempMissing || m.DewpointMissing != tt.dewMissing {
+			t.Errorf("%q/%q: missing = %v/%v, want %v/%v", tt.temperature, tt.dewpoint,
+				m.TemperatureMissing, m.DewpointMissing, tt.tempMissing, tt.dewMissing)
+		}
+		if m.TemperatureC != tt.tempC || m.DewpointC != tt.dewpointC {
+			t.Errorf("%q/%q: decoded %v/%v, want %v/%v", tt.temperature, tt.dewpoint, m.TemperatureC, m.DewpointC, tt.tempC, tt.dewpointC)
+		}
+		if m.Temperature != tt.tempStr || m.TemperatureF != tt.tempF {
+			t.Errorf("%q: Temperature, TemperatureF = %q, %q, want %q, %q", tt.temperature, m.Temperature, m.TemperatureF, tt.tempStr, tt.tempF)
+		}
+		if len(m.DecodeErrors) > 0 {
+			t.Errorf("%q/%q: decode errors %v", tt.temperature, tt.dewpoint, m.DecodeErrors)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		metar Metar
+		want  int
+	}{
+		{"fraction visibility", Metar{Visibility: "1/2"}, 0},
+		{"missing visibility", Metar{Visibility: "////"}, 0},
+		{"zero denominator", Metar{Visibility: "1/0"}, 1},
+		{"missing temperature", Metar{Temperature: "//", Dewpoint: "/////"}, 0},
+		{"partial temperature", Metar{Temperature: "1/", Dewpoint: "/5"}, 2},
+	}
+	for _, tt := range tests {
+		m := decode(tt.metar)
+		if len(m.DecodeErrors) != tt.want {
+			t.Errorf("%s: decode errors %v, want %d", tt.name, m.DecodeErrors, tt.want)
+		}
+	}
+}
+
+func TestMaintenanceNeeded(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002 RMK AO2 $", true},
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002 RMK AO2", false},
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002 RMK AO2 $  ", true},
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002 $ RMK AO2", false},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.MaintenanceNeeded != tt.want {
+			t.Errorf("%q: MaintenanceNeeded = %v, want %v", tt.raw, m.MaintenanceNeeded, tt.want)
+		}
+	}
+}
+
+func TestDecodeRecentWeather(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []ConditionDec
+	}{
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002 RERA", []ConditionDec{{Desc: "RAIN"}}},
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002 RETSRA", []ConditionDec{{Descriptor: "THUNDERSTORM", Desc: "RAIN"}}},
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002", nil},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if len(m.RecentWeather) != len(tt.want) {
+			t.Errorf("%q: RecentWeather = %+v, want %+v", tt.raw, m.RecentWeather, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if m.RecentWeather[i] != tt.want[i] {
+				t.Errorf("%q: RecentWeather[%d] = %+v, want %+v", tt.raw, i, m.RecentWeather[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestFormatICAO(t *testing.T) {
+	tests := []struct {
+		code        string
+		icao, exact string
+		wantErr     bool
+	}{
+		{"sfo", "KSFO", "SFO", false},
+		{"YYZ", "CYYZ", "YYZ", false},
+		{"egll", "EGLL", "EGLL", false},
+		{"SF", "", "", true},
+		{"KSFOX", "", "", true},
+	}
+	for _, tt := range tests {
+		icao, err := FormatICAO(tt.code)
+		if (err != nil) != tt.wantErr || !tt.wantErr && icao != tt.icao {
+			t.Errorf("FormatICAO(%q) = %q, %v, want %q", tt.code, icao, err, tt.icao)
+		}
+		exact, err := FormatICAOExact(tt.code)
+		if (err != nil) != tt.wantErr || !tt.wantErr && exact != tt.exact {
+			t.Errorf("FormatICAOExact(%q) = %q, %v, want %q", tt.code, exact, err, tt.exact)
+		}
+	}
+}
+
+func TestDecodeAltimeter(t *testing.T) {
+	tests := []struct {
+		raw, altimeter string
+		inHg           float64
+		hpa, str       string
+	}{
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A2992", "2992", 29.92, "1013", "29.92"},
+		{"EGLL 221750Z 24015KT 9999 SCT040 12/06 Q1013", "1013", 1013 / hPaPerInHg, "1013", "29.91"},
+		{"", "1013", 1013 / hPaPerInHg, "1013", "29.91"},
+		{"", "3002", 30.02, "1017", "30.02"},
+	}
+	for _, tt := range tests {
+		m := decode(Metar{RawReport: tt.raw, Altimeter: tt.altimeter})
+		if math.Abs(m.AltimeterInHg-tt.inHg) > 0.0001 || m.AltimeterHpa != tt.hpa || m.Altimeter != tt.str {
+			t.Errorf("%q/%q: altimeter = %v inHg, %q hPa, %q, want %v, %q, %q", tt.raw, tt.altimeter,
+				m.AltimeterInHg, m.AltimeterHpa, m.Altimeter, tt.inHg, tt.hpa, tt.str)
+		}
+	}
+}
+
+func TestDecodeTGroup(t *testing.T) {
+	tests := []struct {
+		raw              string
+		decoder          Decoder
+		tempC, dewpointC float64
+		temp, dewpoint   string
+		tempF            string
+		tempMissing      bool
+	}{
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002 RMK AO2 T01830122", Decoder{}, 18.3, 12.2, "18.0", "12.0", "64.4", false},
+		{"KDEN 221753Z 17012KT 10SM OVC008 M02/M03 A2985 RMK AO2 T10171028", Decoder{}, -1.7, -2.8, "-2.0", "-3.0", "28.4", false},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002 RMK AO2 T01830122", Decoder{TemperaturePrecision: Precision(0)}, 18.3, 12.2, "18", "12", "64", false},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002 RMK AO2 T0183", Decoder{}, 18.3, 12, "18.0", "12.0", "64.4", false},
+		{"KSFO 221756Z 28010KT 10SM FEW008 ///// A3002 RMK AO2 T01830122", Decoder{}, 18.3, 12.2, "", "", "", true},
+	}
+	for _, tt := range tests {
+		m, err := tt.decoder.ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if math.Abs(m.TemperatureC-tt.tempC) > 1e-9 || math.Abs(m.DewpointC-tt.dewpointC) > 1e-9 {
+			t.Errorf("%q: TemperatureC, DewpointC = %v, %v, want %v, %v", tt.raw, m.TemperatureC, m.DewpointC, tt.tempC, tt.dewpointC)
+		}
+		if m.Temperature != tt.temp || m.Dewpoint != tt.dewpoint || m.TemperatureF != tt.tempF {
+			t.Errorf("%q: Temperature, Dewpoint, TemperatureF = %q, %q, %q, want %q, %q, %q", tt.raw,
+				m.Temperature, m.Dewpoint, m.TemperatureF, tt.temp, tt.dewpoint, tt.tempF)
+		}
+		if m.TemperatureMissing != tt.tempMissing {
+			t.Errorf("%q: TemperatureMissing = %v, want %v", tt.raw, m.TemperatureMissing, tt.tempMissing)
+		}
+	}
+}
+
+func TestDecodeShortCloudLayers(t *testing.T) {
+	m := decode(Metar{CloudLayers: [][]string{{"SKC"}, {}, {"BKN", "020", "CB"}, {"OVC"}}})
+	want := []CloudLayerDec{
+		{Coverage: "SKY CLEAR"},
+		{Coverage: "BROKEN", HeightFt: "2000", HeightFtInt: 2000, Type: "CUMULONIMBUS"},
+		{Coverage: "OVERCAST"},
+	}
+	if len(m.CloudLayersDec) != len(want) {
+		t.Fatalf("CloudLayersDec = %+v, want %+v", m.CloudLayersDec, want)
+	}
+	for i := range want {
+		if m.CloudLayersDec[i] != want[i] {
+			t.Errorf("CloudLayersDec[%d] = %+v, want %+v", i, m.CloudLayersDec[i], want[i])
+		}
+	}
+}
+
+func TestGetDirectionDesc(t *testing.T) {
+	tests := []struct {
+		degrees int64
+		want    string
+	}{
+		{-45, "NW"},
+		{405, "NE"},
+		{360, "N"},
+		{361, "N"},
+		{0, "N"},
+		{11, "N"},
+		{12, "NNE"},
+		{180, "S"},
+		{-360, "N"},
+	}
+	for _, tt := range tests {
+		if got := GetDirectionDesc(tt.degrees); got != tt.want {
+			t.Errorf("GetDirectionDesc(%d) = %q, want %q", tt.degrees, got, tt.want)
+		}
+	}
+}
+
+// benchmarkReports are representative reports as delivered by the API.
+var benchmarkReports = []Metar{
+	{
+		RawReport:     "KSFO 221756Z 28010G18KT 10SM -RA BR FEW008 BKN200 18/12 A3002 RMK AO2 SLP167 T01830122",
+		Remarks:       "AO2 SLP167 T01830122",
+		WindDirection: "280", WindSpeed: "10", WindGust: "18",
+		Visibility:  "10",
+		Temperature: "18", Dewpoint: "12", Altimeter: "3002",
+		Conditions:  []string{"-RA", "BR"},
+		CloudLayers: [][]string{{"FEW", "008"}, {"BKN", "200"}},
+	},
+	{
+		RawReport:     "EGLL 221750Z 24015KT 9999 SCT040 BKN025CB 12/06 Q1013 TEMPO SHRA",
+		WindDirection: "240", WindSpeed: "15",
+		Visibility:  "9999",
+		Temperature: "12", Dewpoint: "06", Altimeter: "1013",
+		CloudLayers: [][]string{{"SCT", "040"}, {"BKN", "025", "CB"}},
+	},
+	{
+		RawReport:     "KORD 221751Z VRB03KT 1/2SM FG VV002 M02/M03 A2992",
+		WindDirection: "VRB", WindSpeed: "03",
+		Visibility:  "1/2",
+		Temperature: "M02", Dewpoint: "M03", Altimeter: "2992",
+		Conditions:  []string{"FG"},
+		CloudLayers: [][]string{{"VV", "002"}},
+	},
+}
+
+func BenchmarkDecodeMetar(b *testing.B) {
+	d := new(Decoder)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, report := range benchmarkReports {
+			metar := report
+			d.decodeMetar(&metar)
+		}
+	}
+}
+
+// TestDecodeMetarOutput pins the formatted fields of the benchmark reports, which were produced
+// with fmt.Sprintf before decoding was optimized.
+func TestDecodeMetarOutput(t *testing.T) {
+	type output struct {
+		temp, tempF, dewpoint, dewpointF string
+		altimeter, altimeterHpa          string
+		windDesc                         string
+		heights                          []string
+		conditions                       []ConditionDec
+	}
+	want := []output{
+		{"18.0", "64.4", "12.0", "53.6", "30.02", "1017", "W", []string{"800", "20000"},
+			[]ConditionDec{{Modifier: "LIGHT", Desc: "RAIN"}, {Desc: "MIST"}}},
+		{"12.0", "53.6", "6.0", "42.8", "29.91", "1013", "WSW", []string{"4000", "2500"}, nil},
+		{"-2.0", "28.4", "-3.0", "26.6", "29.92", "1013", "VARIABLE", []string{"200"},
+			[]ConditionDec{{Desc: "FOG"}}},
+	}
+	for i, report := range benchmarkReports {
+		m := decode(report)
+		got := output{m.Temperature, m.TemperatureF, m.Dewpoint, m.DewpointF, m.Altimeter, m.AltimeterHpa, m.WindDirectionDesc, nil, m.ConditionsDec}
+		for _, layer := range m.CloudLayersDec {
+			got.heights = append(got.heights, layer.HeightFt)
+		}
+		if got.temp != want[i].temp || got.tempF != want[i].tempF || got.dewpoint != want[i].dewpoint || got.dewpointF != want[i].dewpointF ||
+			got.altimeter != want[i].altimeter || got.altimeterHpa != want[i].altimeterHpa || got.windDesc != want[i].windDesc ||
+			!equalStrings(got.heights, want[i].heights) || len(got.conditions) != len(want[i].conditions) {
+			t.Errorf("%s: decoded %+v, want %+v", report.RawReport, got, want[i])
+			continue
+		}
+		for j := range got.conditions {
+			if got.conditions[j] != want[i].conditions[j] {
+				t.Errorf("%s: ConditionsDec[%d] = %+v, want %+v", report.RawReport, j, got.conditions[j], want[i].conditions[j])
+			}
+		}
+		if len(m.DecodeErrors) > 0 {
+			t.Errorf("%s: decode errors %v", report.RawReport, m.DecodeErrors)
+		}
+	}
+}
+
+func TestNumericFieldsAgree(t *testing.T) {
+	reports := []string{
+		"KSFO 221756Z 28010G18KT 10SM FEW008 BKN200 18/12 A3002",
+		"KORD 221751Z 36005KT 1/2SM FG VV002 M02/M03 A2992",
+		"KDEN 221753Z 17012KT 1 1/2SM -SN BR OVC008 M01/M02 A2985 RMK AO2 T10111022",
+		"EGLL 221750Z 24015KT 9999 SCT040 12/06 Q1013",
+	}
+	for _, raw := range reports {
+		m, err := ParseMetar(raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", raw, err)
+		}
+		agree := func(name, str string, value, tolerance float64) {
+			parsed, err := strconv.ParseFloat(str, 64)
+			if err != nil || math.Abs(parsed-value) > tolerance {
+				t.Errorf("%q: %s = %q, numeric value %v", raw, name, str, value)
+			}
+		}
+		// The strings keep the whole degrees of the body while a T group gives the Celsius values
+		// to a tenth, so they agree to half a degree.
+		agree("Temperature", m.Temperature, m.TemperatureC, 0.5)
+		agree("TemperatureF", m.TemperatureF, cToF(m.TemperatureC), 0.9)
+		agree("Dewpoint", m.Dewpoint, m.DewpointC, 0.5)
+		agree("Altimeter", m.Altimeter, m.AltimeterInHg, 0.005)
+		agree("AltimeterHpa", m.AltimeterHpa, m.AltimeterInHg*hPaPerInHg, 0.5)
+		agree("WindSpeed", m.WindSpeed, float64(m.WindSpeedKt), 0)
+		agree("WindDirection", m.WindDirection, float64(m.WindDirectionDeg), 0)
+		if m.WindGust != "" || m.WindGustKt != 0 {
+			agree("WindGust", m.WindGust, float64(m.WindGustKt), 0)
+		}
+	}
+}
+
+func TestDecodeCorrection(t *testing.T) {
+	tests := []struct {
+		raw           string
+		corrected     bool
+		correctionSeq string
+	}{
+		{"KSFO 221756Z COR 28010KT 10SM FEW008 18/12 A3002", true, ""},
+		{"EGLL 221750Z CCA 24015KT 9999 SCT040 12/06 Q1013", true, "A"},
+		{"EGLL 221750Z CCB 24015KT 9999 SCT040 12/06 Q1013", true, "B"},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002", false, ""},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.Corrected != tt.corrected || m.CorrectionSeq != tt.correctionSeq {
+			t.Errorf("%q: Corrected, CorrectionSeq = %v, %q, want %v, %q", tt.raw, m.Corrected, m.CorrectionSeq, tt.corrected, tt.correctionSeq)
+		}
+	}
+}
+
+func TestParseSigned(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"M45", -45, false},
+		{"45", 45, false},
+		{" M05 ", -5, false},
+		{"00", 0, false},
+		{"M", 0, true},
+		{"", 0, true},
+		{"4X", 0, true},
+		{"MM5", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSigned(tt.value)
+		if (err != nil) != tt.wantErr || !tt.wantErr && got != tt.want {
+			t.Errorf("parseSigned(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestDecodeCalmWind(t *testing.T) {
+	tests := []struct {
+		raw   string
+		calm  bool
+		desc  string
+		deg   int
+		arrow string
+	}{
+		{"KSFO 221756Z 00000KT 10SM FEW008 18/12 A3002", true, "CALM", 0, "·"},
+		{"KSFO 221756Z 36005KT 10SM FEW008 18/12 A3002", false, "N", 360, "↓"},
+		{"KSFO 221756Z 00005KT 10SM FEW008 18/12 A3002", false, "N", 0, "↓"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.Calm != tt.calm || m.IsCalm() != tt.calm || m.WindDirectionDesc != tt.desc || m.WindDirectionDeg != tt.deg || m.WindArrow() != tt.arrow {
+			t.Errorf("%q: Calm, WindDirectionDesc, WindDirectionDeg, WindArrow = %v, %q, %d, %q, want %v, %q, %d, %q", tt.raw,
+				m.Calm, m.WindDirectionDesc, m.WindDirectionDeg, m.WindArrow(), tt.calm, tt.desc, tt.deg, tt.arrow)
+		}
+	}
+}
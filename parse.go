@@ -0,0 +1,201 @@
+package avwx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	stationPattern     = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	timePattern        = regexp.MustCompile(`^\d{6}Z$`)
+	windPattern        = regexp.MustCompile(`^(\d{3}|VRB)(\d{2,3})(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	windVarPattern     = regexp.MustCompile(`^\d{3}V\d{3}$`)
+	visibilityPattern  = regexp.MustCompile(`^([PM]?\d+(?:/\d+)?)SM$`)
+	visWholePattern    = regexp.MustCompile(`^\d+$`)
+	visFractionPattern = regexp.MustCompile(`^\d+/\d+SM$`)
+	visMetersPattern   = regexp.MustCompile(`^\d{4}$`)
+	rvrPattern         = regexp.MustCompile(`^R\d{2}[LRC]?/`)
+	weatherPattern     = regexp.MustCompile(`^(?:-|\+|VC)?(?:MI|PR|BC|DR|BL|SH|TS|FZ)?(?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*$`)
+	cloudPattern       = regexp.MustCompile(`^([A-Z]{2,3}|///)(\d{3}|///)([A-Z]{2,5}|///)?$`)
+	tempPattern        = regexp.MustCompile(`^(M?\d{2}|//)/(M?\d{2}|//)?$`)
+	altimeterPattern   = regexp.MustCompile(`^[AQ]\d{4}$`)
+	cycleTimePattern   = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}$`)
+)
+
+// ParseMetar decodes a raw METAR report without fetching it from the API.
+func ParseMetar(raw string) (*Metar, error) {
+	return new(Decoder).ParseMetar(raw)
+}
+
+// ParseMetar decodes a raw METAR report using the decoder's settings.
+func (d *Decoder) ParseMetar(raw string) (*Metar, error) {
+	metar, err := parseRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+	d.decodeMetar(metar)
+	return metar, nil
+}
+
+// parseRaw splits a raw METAR report into the fields the API delivers, leaving them undecoded.
+func parseRaw(raw string) (*Metar, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("Empty report")
+	}
+
+	metar := new(Metar)
+	metar.RawReport = raw
+
+	body := raw
+	if i := strings.Index(raw, " RMK "); i >= 0 {
+		metar.Remarks = raw[i+5:]
+		body = raw[:i]
+	}
+
+	tokens := strings.Fields(body)
+	if len(tokens) > 0 && (tokens[0] == "METAR" || tokens[0] == "SPECI") {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 || !stationPattern.MatchString(tokens[0]) {
+		return nil, fmt.Errorf("Invalid station in report: %s", raw)
+	}
+	metar.Station = tokens[0]
+	tokens = tokens[1:]
+
+	if len(tokens) > 0 && timePattern.MatchString(tokens[0]) {
+		metar.Time = tokens[0]
+		tokens = tokens[1:]
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if trendTypes[token] {
+			// The remaining groups forecast a change rather than describe the observation.
+			break
+		}
+		switch {
+		case token == "AUTO" || token == "COR":
+			continue
+		case windPattern.MatchString(token):
+			match := windPattern.FindStringSubmatch(token)
+			metar.WindDirection = match[1]
+			metar.WindSpeed = windKnots(match[2], match[4])
+			metar.WindGust = windKnots(match[3], match[4])
+		case windVarPattern.MatchString(token):
+			continue
+		case visWholePattern.MatchString(token) && i+1 < len(tokens) && visFractionPattern.MatchString(tokens[i+1]):
+			metar.Visibility = token + " " + strings.TrimSuffix(tokens[i+1], "SM")
+			i++
+		case visibilityPattern.MatchString(token):
+			metar.Visibility = visibilityPattern.FindStringSubmatch(token)[1]
+		case visMetersPattern.MatchString(token) && metar.Visibility == "":
+			metar.Visibility = token
+		case token == "CAVOK":
+			metar.Visibility = "9999"
+		case rvrPattern.MatchString(token):
+			continue
+		case isCloudGroup(token):
+			metar.CloudLayers = append(metar.CloudLayers, cloudLayer(token))
+		case tempPattern.MatchString(token):
+			match := tempPattern.FindStringSubmatch(token)
+			if match[1] != "//" {
+				metar.Temperature = match[1]
+			}
+			if match[2] != "//" {
+				metar.Dewpoint = match[2]
+			}
+		case altimeterPattern.MatchString(token):
+			// Keep the first setting of a report carrying both an A and a Q group.
+			if metar.Altimeter == "" {
+				metar.Altimeter = token[1:]
+			}
+		case isWeatherGroup(token):
+			metar.Conditions = append(metar.Conditions, token)
+		}
+	}
+	return metar, nil
+}
+
+// ParseMetarStream decodes every report in a NOAA cycle file, one report per line.
+// Blank lines, comments and the timestamp lines preceding each report are skipped. Reports which fail
+// to parse are skipped as well, and the returned error joins their errors as ParseMetarBatch gives them.
+func ParseMetarStream(r io.Reader) ([]*Metar, error) {
+	metars, errs := ParseMetarBatch(r)
+	return metars, errors.Join(errs...)
+}
+
+// ParseMetarBatch decodes every report in r, one report per line, like ParseMetarStream but returning
+// the errors separately. The returned errors give the line number of each failure.
+func ParseMetarBatch(r io.Reader) ([]*Metar, []error) {
+	var metars []*Metar
+	var errs []error
+
+	err := scanReports(r, func(lineNum int, line string) {
+		metar, err := ParseMetar(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Line %d: %v", lineNum, err))
+			return
+		}
+		metars = append(metars, metar)
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return metars, errs
+}
+
+// scanReports calls fn with each report line in r and its line number, skipping blank lines, comments
+// and the timestamp lines of NOAA cycle files.
+func scanReports(r io.Reader, fn func(lineNum int, line string)) error {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || cycleTimePattern.MatchString(line) {
+			continue
+		}
+		fn(lineNum, line)
+	}
+	return scanner.Err()
+}
+
+// windKnots converts a wind speed reported in the given unit, KT, MPS or KMH, to whole knots as the
+// API reports it.
+func windKnots(speed, unit string) string {
+	if speed == "" || unit == "KT" {
+		return speed
+	}
+	value, _ := strconv.Atoi(speed)
+	factor := 1 / kmhPerKt
+	if unit == "MPS" {
+		factor = 3.6 / kmhPerKt
+	}
+	return fmt.Sprintf("%02d", int(math.Round(float64(value)*factor)))
+}
+
+// cloudLayer splits a cloud group such as "BKN025CB" into the API's coverage, height and type form.
+func cloudLayer(group string) []string {
+	match := cloudPattern.FindStringSubmatch(group)
+	layer := []string{match[1], match[2]}
+	if match[3] != "" {
+		layer = append(layer, match[3])
+	}
+	return layer
+}
+
+// reportGroups returns the groups of a raw report preceding the remarks.
+func reportGroups(raw string) []string {
+	if i := strings.Index(raw, " RMK"); i >= 0 {
+		raw = raw[:i]
+	}
+	return strings.Fields(raw)
+}
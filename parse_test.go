@@ -0,0 +1,225 @@
+package avwx
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseMetar(t *testing.T) {
+	tests := []struct {
+		raw          string
+		station      string
+		time         string
+		windDeg      int
+		windKt       int
+		gustKt       int
+		visibilitySM float64
+		tempC        float64
+		dewpointC    float64
+		altimeter    float64
+		layers       int
+		conditions   []string
+		flightRules  string
+	}{
+		{
+			raw:          "KSFO 221756Z 28010G18KT 10SM FEW008 BKN200 18/12 A3002 RMK AO2 SLP167",
+			station:      "KSFO",
+			time:         "221756Z",
+			windDeg:      280,
+			windKt:       10,
+			gustKt:       18,
+			visibilitySM: 10,
+			tempC:        18,
+			dewpointC:    12,
+			altimeter:    30.02,
+			layers:       2,
+			flightRules:  "VFR",
+		},
+		{
+			raw:          "METAR EGLL 221750Z 24015KT 9999 SCT040 BKN025 12/06 Q1013 NOSIG",
+			station:      "EGLL",
+			time:         "221750Z",
+			windDeg:      240,
+			windKt:       15,
+			visibilitySM: 9999 / metersPerStatuteMile,
+			tempC:        12,
+			dewpointC:    6,
+			altimeter:    1013 / hPaPerInHg,
+			layers:       2,
+			flightRules:  "MVFR",
+		},
+		{
+			raw:          "KORD 221751Z 36005MPS 1/2SM FG VV002 M02/M03 A2992",
+			station:      "KORD",
+			time:         "221751Z",
+			windDeg:      360,
+			windKt:       10,
+			visibilitySM: 0.5,
+			tempC:        -2,
+			dewpointC:    -3,
+			altimeter:    29.92,
+			layers:       1,
+			conditions:   []string{"FG"},
+			flightRules:  "LIFR",
+		},
+		{
+			raw:          "SPECI UUEE 221800Z 09036KMH CAVOK M05/M10 Q1025",
+			station:      "UUEE",
+			time:         "221800Z",
+			windDeg:      90,
+			windKt:       19,
+			visibilitySM: 9999 / metersPerStatuteMile,
+			tempC:        -5,
+			dewpointC:    -10,
+			altimeter:    1025 / hPaPerInHg,
+			flightRules:  "VFR",
+		},
+		{
+			raw:          "KDEN 221753Z AUTO 17012KT 1 1/2SM -SN BR OVC008 M01/M02 A2985",
+			station:      "KDEN",
+			time:         "221753Z",
+			windDeg:      170,
+			windKt:       12,
+			visibilitySM: 1.5,
+			tempC:        -1,
+			dewpointC:    -2,
+			altimeter:    29.85,
+			layers:       1,
+			conditions:   []string{"-SN", "BR"},
+			flightRules:  "IFR",
+		},
+	}
+
+	for _, tt := range tests {
+		metar, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Errorf("ParseMetar(%q) error: %v", tt.raw, err)
+			continue
+		}
+		if metar.Station != tt.station || metar.Time != tt.time {
+			t.Errorf("ParseMetar(%q) station, time = %q, %q, want %q, %q", tt.raw, metar.Station, metar.Time, tt.station, tt.time)
+		}
+		if metar.WindDirectionDeg != tt.windDeg || metar.WindSpeedKt != tt.windKt || metar.WindGustKt != tt.gustKt {
+			t.Errorf("ParseMetar(%q) wind = %d° %d kt gusting %d, want %d° %d kt gusting %d", tt.raw,
+				metar.WindDirectionDeg, metar.WindSpeedKt, metar.WindGustKt, tt.windDeg, tt.windKt, tt.gustKt)
+		}
+		if math.Abs(metar.VisibilitySM-tt.visibilitySM) > 0.001 {
+			t.Errorf("ParseMetar(%q).VisibilitySM = %v, want %v", tt.raw, metar.VisibilitySM, tt.visibilitySM)
+		}
+		if metar.TemperatureC != tt.tempC || metar.DewpointC != tt.dewpointC {
+			t.Errorf("ParseMetar(%q) temperature/dewpoint = %v/%v, want %v/%v", tt.raw, metar.TemperatureC, metar.DewpointC, tt.tempC, tt.dewpointC)
+		}
+		if math.Abs(metar.AltimeterInHg-tt.altimeter) > 0.001 {
+			t.Errorf("ParseMetar(%q).AltimeterInHg = %v, want %v", tt.raw, metar.AltimeterInHg, tt.altimeter)
+		}
+		if len(metar.CloudLayersDec) != tt.layers {
+			t.Errorf("ParseMetar(%q) has %d cloud layers, want %d", tt.raw, len(metar.CloudLayersDec), tt.layers)
+		}
+		if !equalStrings(metar.Conditions, tt.conditions) {
+			t.Errorf("ParseMetar(%q).Conditions = %q, want %q", tt.raw, metar.Conditions, tt.conditions)
+		}
+		if metar.FlightRules != tt.flightRules {
+			t.Errorf("ParseMetar(%q).FlightRules = %q, want %q", tt.raw, metar.FlightRules, tt.flightRules)
+		}
+		if len(metar.DecodeErrors) > 0 {
+			t.Errorf("ParseMetar(%q) decode errors: %v", tt.raw, metar.DecodeErrors)
+		}
+	}
+}
+
+func TestParseMetarInvalid(t *testing.T) {
+	for _, raw := range []string{"", "   ", "METAR", "12345 221756Z 28010KT"} {
+		if metar, err := ParseMetar(raw); err == nil {
+			t.Errorf("ParseMetar(%q) = %+v, want error", raw, metar)
+		}
+	}
+}
+
+func TestWindKnots(t *testing.T) {
+	tests := []struct {
+		speed, unit, want string
+	}{
+		{"10", "KT", "10"},
+		{"", "MPS", ""},
+		{"05", "MPS", "10"},
+		{"36", "KMH", "19"},
+		{"100", "KMH", "54"},
+	}
+	for _, tt := range tests {
+		if got := windKnots(tt.speed, tt.unit); got != tt.want {
+			t.Errorf("windKnots(%q, %q) = %q, want %q", tt.speed, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+const cycleFixture = `# NOAA cycle file
+2024/06/22 17:56
+KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002
+
+2024/06/22 17:50
+EGLL 221750Z 24015KT 9999 SCT040 12/06 Q1013 NOSIG
+2024/06/22 17:51
+KORD 221751Z 36010KT 1/2SM FG VV002 M02/M03 A2992
+`
+
+func TestParseMetarStream(t *testing.T) {
+	metars, err := ParseMetarStream(strings.NewReader(cycleFixture))
+	if err != nil {
+		t.Fatalf("ParseMetarStream error: %v", err)
+	}
+	want := []string{"KSFO", "EGLL", "KORD"}
+	if len(metars) != len(want) {
+		t.Fatalf("ParseMetarStream returned %d reports, want %d", len(metars), len(want))
+	}
+	for i, metar := range metars {
+		if metar.Station != want[i] {
+			t.Errorf("report %d station = %q, want %q", i, metar.Station, want[i])
+		}
+	}
+}
+
+const badCycleFixture = `2024/06/22 17:56
+KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002
+2024/06/22 17:50
+12345 221750Z 24015KT 9999 SCT040 12/06 Q1013
+METAR
+KORD 221751Z 36010KT 1/2SM FG VV002 M02/M03 A2992
+`
+
+func TestParseMetarBatchBadLines(t *testing.T) {
+	metars, errs := ParseMetarBatch(strings.NewReader(badCycleFixture))
+	if len(metars) != 2 || metars[0].Station != "KSFO" || metars[1].Station != "KORD" {
+		t.Errorf("ParseMetarBatch returned %d reports, want KSFO and KORD", len(metars))
+	}
+	if len(errs) != 2 {
+		t.Fatalf("ParseMetarBatch errors = %v, want 2", errs)
+	}
+	for i, prefix := range []string{"Line 4: ", "Line 5: "} {
+		if !strings.HasPrefix(errs[i].Error(), prefix) {
+			t.Errorf("error %d = %q, want prefix %q", i, errs[i], prefix)
+		}
+	}
+}
+
+func TestParseMetarStreamBadLines(t *testing.T) {
+	metars, err := ParseMetarStream(strings.NewReader(badCycleFixture))
+	if len(metars) != 2 {
+		t.Errorf("ParseMetarStream returned %d reports, want 2", len(metars))
+	}
+	if err == nil || !strings.Contains(err.Error(), "Line 4: ") || !strings.Contains(err.Error(), "Line 5: ") {
+		t.Errorf("ParseMetarStream error = %v, want lines 4 and 5", err)
+	}
+}
@@ -0,0 +1,155 @@
+package avwx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const pirepURL = "https://avwx.rest/api/pirep/"
+
+// Pirep is a pilot report decoded from its raw form, such as
+// "OKC UA /OV OKC063015 /TM 1522 /FL085 /TP C172 /SK BKN065 /TA M05 /TB LGT /IC LGT RIME".
+// Fields not included in the report are left empty.
+type Pirep struct {
+	Raw         string
+	Station     string
+	Urgent      bool
+	Location    string
+	Time        string
+	Altitude    string
+	AltitudeFt  int
+	Aircraft    string
+	Sky         string
+	Temperature string
+	Wind        string
+	Turbulence  string
+	Icing       string
+	Weather     string
+	Remarks     string
+}
+
+// PirepResponse holds the pilot reports near a station.
+type PirepResponse struct {
+	Station string
+	Reports []Pirep
+}
+
+// WithPirepURL sets the endpoint pilot reports are fetched from.
+func WithPirepURL(endpoint string) Option {
+	return func(c *Client) {
+		c.pirepURL = endpoint
+	}
+}
+
+// FetchPirep fetches the pilot reports near the station using DefaultClient.
+func FetchPirep(station string) (*PirepResponse, error) {
+	return DefaultClient.FetchPirep(station)
+}
+
+// FetchPirep fetches the pilot reports near the station. Each report is decoded locally from its
+// raw text, so only the raw report is needed from the API.
+func (c *Client) FetchPirep(station string) (*PirepResponse, error) {
+	resp, err := c.get(context.Background(), station, c.pirepURL+station)
+	if err != nil {
+		return nil, newFetchError(station, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newFetchError(station, fmt.Errorf("Query failed: %s", resp.Status))
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, newFetchError(station, wrapTimeout(err))
+	}
+	// The reports are either listed directly or wrapped in a data field.
+	var reports []struct {
+		Raw string `json:"raw"`
+	}
+	if trimmed := strings.TrimSpace(string(body)); strings.HasPrefix(trimmed, "{") {
+		var wrapped struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return nil, newFetchError(station, err)
+		}
+		body = wrapped.Data
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &reports); err != nil {
+			return nil, newFetchError(station, err)
+		}
+	}
+
+	pirepResp := &PirepResponse{Station: station}
+	for _, report := range reports {
+		if pirep, err := ParsePirep(report.Raw); err == nil {
+			pirepResp.Reports = append(pirepResp.Reports, *pirep)
+		}
+	}
+	return pirepResp, nil
+}
+
+// ParsePirep decodes a raw pilot report. Each field is identified by its slash code, such as /OV for
+// the location or /TB for turbulence.
+func ParsePirep(raw string) (*Pirep, error) {
+	raw = strings.TrimSpace(raw)
+	// Remarks come last and are free text which may contain slashes.
+	body, remarks, _ := strings.Cut(raw, "/RM ")
+	parts := strings.Split(body, "/")
+	header := strings.Fields(parts[0])
+	if len(parts) < 2 || len(header) == 0 {
+		return nil, fmt.Errorf("Invalid pilot report: %s", raw)
+	}
+
+	pirep := &Pirep{Raw: raw, Remarks: strings.TrimSpace(remarks)}
+	for _, field := range header {
+		switch field {
+		case "UA":
+		case "UUA":
+			pirep.Urgent = true
+		default:
+			pirep.Station = field
+		}
+	}
+
+	for _, part := range parts[1:] {
+		code, value, _ := strings.Cut(strings.TrimSpace(part), " ")
+		value = strings.TrimSpace(value)
+		switch code {
+		case "OV":
+			pirep.Location = value
+		case "TM":
+			pirep.Time = value
+		case "TP":
+			pirep.Aircraft = value
+		case "SK":
+			pirep.Sky = value
+		case "TA":
+			pirep.Temperature = value
+		case "WV":
+			pirep.Wind = value
+		case "TB":
+			pirep.Turbulence = value
+		case "IC":
+			pirep.Icing = value
+		case "WX":
+			pirep.Weather = value
+		default:
+			// The altitude has no space after its code, as in "/FL085".
+			if strings.HasPrefix(code, "FL") {
+				pirep.Altitude = code[2:]
+				if hundreds, err := strconv.Atoi(pirep.Altitude); err == nil {
+					pirep.AltitudeFt = hundreds * 100
+				}
+			}
+		}
+	}
+	return pirep, nil
+}
@@ -0,0 +1,69 @@
+package avwx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testPirepRaw = "OKC UA /OV OKC063015 /TM 1522 /FL085 /TP C172 /SK BKN065 /TA M05 /TB LGT /IC LGT RIME /RM SMOOTH ABV/BLO"
+
+func TestParsePirep(t *testing.T) {
+	pirep, err := ParsePirep(testPirepRaw)
+	if err != nil {
+		t.Fatalf("ParsePirep error: %v", err)
+	}
+	want := Pirep{
+		Raw:         testPirepRaw,
+		Station:     "OKC",
+		Location:    "OKC063015",
+		Time:        "1522",
+		Altitude:    "085",
+		AltitudeFt:  8500,
+		Aircraft:    "C172",
+		Sky:         "BKN065",
+		Temperature: "M05",
+		Turbulence:  "LGT",
+		Icing:       "LGT RIME",
+		Remarks:     "SMOOTH ABV/BLO",
+	}
+	if *pirep != want {
+		t.Errorf("ParsePirep = %+v, want %+v", *pirep, want)
+	}
+
+	if pirep, err := ParsePirep("DEN UUA /OV DEN /TM 1600 /FL350 /TP B738 /TB SEV"); err != nil || !pirep.Urgent || pirep.AltitudeFt != 35000 {
+		t.Errorf("ParsePirep of an urgent report = %+v, %v", pirep, err)
+	}
+	for _, raw := range []string{"", "OKC UA", "/OV OKC"} {
+		if pirep, err := ParsePirep(raw); err == nil {
+			t.Errorf("ParsePirep(%q) = %+v, want error", raw, pirep)
+		}
+	}
+}
+
+func TestFetchPirep(t *testing.T) {
+	bodies := []string{
+		`[{"raw": "` + testPirepRaw + `"}, {"raw": "not a report"}]`,
+		`{"data": [{"raw": "` + testPirepRaw + `"}, {"raw": "not a report"}]}`,
+	}
+	for _, body := range bodies {
+		var path string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}))
+
+		pirepResp, err := NewClient(WithPirepURL(srv.URL + "/pirep/")).FetchPirep("KOKC")
+		srv.Close()
+		if err != nil {
+			t.Fatalf("FetchPirep error: %v", err)
+		}
+		if path != "/pirep/KOKC" {
+			t.Errorf("requested %q, want /pirep/KOKC", path)
+		}
+		if pirepResp.Station != "KOKC" || len(pirepResp.Reports) != 1 || pirepResp.Reports[0].Icing != "LGT RIME" {
+			t.Errorf("FetchPirep(%s) = %+v", body, pirepResp)
+		}
+	}
+}
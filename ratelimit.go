@@ -0,0 +1,63 @@
+package avwx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit spaces the client's requests evenly so no more than requestsPerMinute are made each
+// minute, keeping within the API quota. Requests wait their turn before being sent, giving up when
+// their context is done. A limit of zero or less disables rate limiting, which is the default.
+func WithRateLimit(requestsPerMinute int) Option {
+	return func(c *Client) {
+		if requestsPerMinute <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = &rateLimiter{interval: time.Minute / time.Duration(requestsPerMinute)}
+	}
+}
+
+// rateLimiter hands out request slots at a fixed interval.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next request slot, returning the context's error if it is done first.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	slot := l.next
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(slot)
+		return ctx.Err()
+	}
+}
+
+// release gives back an unused slot when no later slot has been handed out, so a cancelled wait
+// does not hold back the next request.
+func (l *rateLimiter) release(slot time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.next.Equal(slot.Add(l.interval)) {
+		l.next = slot
+	}
+}
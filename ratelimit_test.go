@@ -0,0 +1,71 @@
+package avwx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var arrivals []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+		metarHandler(w, r)
+	}))
+	defer srv.Close()
+
+	// 1200 requests a minute is one every 50ms.
+	const calls, interval = 5, 50 * time.Millisecond
+	client := NewClient(WithBaseURL(srv.URL+"/"), WithRateLimit(1200))
+	start := time.Now()
+	for i := 0; i < calls; i++ {
+		if metarResp := client.FetchMetar("KSFO"); metarResp.Error != nil {
+			t.Fatalf("FetchMetar error: %v", metarResp.Error)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < (calls-1)*interval {
+		t.Errorf("%d calls took %v, want at least %v", calls, elapsed, (calls-1)*interval)
+	}
+	// Allow for the time each request takes to reach the server.
+	for i := 1; i < len(arrivals); i++ {
+		if gap := arrivals[i].Sub(arrivals[i-1]); gap < interval/2 {
+			t.Errorf("request %d arrived %v after the previous one, want about %v", i, gap, interval)
+		}
+	}
+}
+
+func TestRateLimitContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL+"/"), WithRateLimit(1))
+	if metarResp := client.FetchMetar("KSFO"); metarResp.Error != nil {
+		t.Fatalf("FetchMetar error: %v", metarResp.Error)
+	}
+
+	// The next slot is a minute away, so the wait gives up with the context.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	metarResp := client.FetchMetarContext(ctx, "KSFO")
+	if metarResp.Error == nil {
+		t.Fatal("FetchMetarContext succeeded before the next slot")
+	}
+	if !errors.Is(metarResp.Error, context.DeadlineExceeded) && !errors.Is(metarResp.Error, ErrTimeout) {
+		t.Errorf("FetchMetarContext error = %v, want the context's error", metarResp.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("FetchMetarContext waited %v after its context was done", elapsed)
+	}
+
+	if NewClient(WithRateLimit(0)).limiter != nil {
+		t.Error("WithRateLimit(0) enabled rate limiting")
+	}
+}
@@ -0,0 +1,190 @@
+package avwx
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	peakWindPattern  = regexp.MustCompile(`^(\d{3})(\d{2,3})/(\d{2}|\d{4})$`)
+	windShiftPattern = regexp.MustCompile(`^\d{2}(?:\d{2})?$`)
+	slpPattern       = regexp.MustCompile(`^SLP(\d{3})$`)
+	precipPattern    = regexp.MustCompile(`^[P67]\d{4}$`)
+	temp6HourPattern = regexp.MustCompile(`^[12][01]\d{3}$`)
+	tendencyPattern  = regexp.MustCompile(`^5[0-8]\d{3}$`)
+	tGroupPattern    = regexp.MustCompile(`^T([01]\d{3})([01]\d{3})?$`)
+)
+
+// RemarksDec holds the groups decoded from a report's remarks.
+type RemarksDec struct {
+	PeakWind  *PeakWindDec
+	WindShift *WindShiftDec
+	// SeaLevelPressureHpa is zero when the report has no SLP group.
+	SeaLevelPressureHpa float64
+	// Precipitation amounts in inches, nil when not reported. A trace is reported as zero.
+	PrecipHourly   *float64
+	Precip3Or6Hour *float64
+	Precip24Hour   *float64
+	// The maximum and minimum temperatures in Celsius over the past 6 hours, nil when not reported.
+	MaxTemp6Hour     *float64
+	MinTemp6Hour     *float64
+	PressureTendency *PressureTendencyDec
+	// The temperature and dewpoint in Celsius to a tenth of a degree from a T group such as "T01830122",
+	// nil when not reported. The dewpoint may be omitted from the group.
+	Temperature *float64
+	Dewpoint    *float64
+}
+
+// clone returns a copy of the remarks sharing no pointers with the original.
+func (r RemarksDec) clone() RemarksDec {
+	if r.PeakWind != nil {
+		peakWind := *r.PeakWind
+		r.PeakWind = &peakWind
+	}
+	if r.WindShift != nil {
+		windShift := *r.WindShift
+		r.WindShift = &windShift
+	}
+	r.PrecipHourly = cloneFloat(r.PrecipHourly)
+	r.Precip3Or6Hour = cloneFloat(r.Precip3Or6Hour)
+	r.Precip24Hour = cloneFloat(r.Precip24Hour)
+	if r.PressureTendency != nil {
+		tendency := *r.PressureTendency
+		r.PressureTendency = &tendency
+	}
+	r.MaxTemp6Hour = cloneFloat(r.MaxTemp6Hour)
+	r.MinTemp6Hour = cloneFloat(r.MinTemp6Hour)
+	r.Temperature = cloneFloat(r.Temperature)
+	r.Dewpoint = cloneFloat(r.Dewpoint)
+	return r
+}
+
+func cloneFloat(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	value := *f
+	return &value
+}
+
+// PeakWindDec is a peak wind remark such as "PK WND 28045/1955". Time is either "hhmm" or,
+// when the peak occurred within the same hour as the report, "mm".
+type PeakWindDec struct {
+	DirectionDeg int
+	SpeedKt      int
+	Time         string
+}
+
+// PressureTendencyDec is a 3 hour pressure tendency remark such as "52032". ChangeHpa is negative
+// when the pressure is lower than 3 hours ago.
+type PressureTendencyDec struct {
+	Character int
+	Desc      string
+	ChangeHpa float64
+}
+
+var tendencyCharacters = []string{
+	"INCREASING, THEN DECREASING",
+	"INCREASING, THEN STEADY",
+	"INCREASING",
+	"DECREASING OR STEADY, THEN INCREASING",
+	"STEADY",
+	"DECREASING, THEN INCREASING",
+	"DECREASING, THEN STEADY",
+	"DECREASING",
+	"STEADY OR INCREASING, THEN DECREASING",
+}
+
+// WindShiftDec is a wind shift remark such as "WSHFT 1715 FROPA". Time is "hhmm" or "mm".
+type WindShiftDec struct {
+	Time    string
+	Frontal bool
+}
+
+// decodeRemarks decodes the known groups of a report's remarks, ignoring anything it does not recognize.
+func decodeRemarks(remarks string) RemarksDec {
+	var dec RemarksDec
+	groups := strings.Fields(remarks)
+	for i := 0; i < len(groups); i++ {
+		switch {
+		case groups[i] == "PK" && i+2 < len(groups) && groups[i+1] == "WND":
+			match := peakWindPattern.FindStringSubmatch(groups[i+2])
+			if match == nil {
+				continue
+			}
+			direction, _ := strconv.Atoi(match[1])
+			speed, _ := strconv.Atoi(match[2])
+			dec.PeakWind = &PeakWindDec{DirectionDeg: direction, SpeedKt: speed, Time: match[3]}
+			i += 2
+		case groups[i] == "WSHFT" && i+1 < len(groups) && windShiftPattern.MatchString(groups[i+1]):
+			dec.WindShift = &WindShiftDec{Time: groups[i+1]}
+			i++
+			if i+1 < len(groups) && groups[i+1] == "FROPA" {
+				dec.WindShift.Frontal = true
+				i++
+			}
+		case slpPattern.MatchString(groups[i]):
+			dec.SeaLevelPressureHpa = seaLevelPressure(groups[i][3:])
+		case precipPattern.MatchString(groups[i]):
+			hundredths, _ := strconv.Atoi(groups[i][1:])
+			inches := float64(hundredths) / 100
+			switch groups[i][0] {
+			case 'P':
+				dec.PrecipHourly = &inches
+			case '6':
+				dec.Precip3Or6Hour = &inches
+			case '7':
+				dec.Precip24Hour = &inches
+			}
+		case temp6HourPattern.MatchString(groups[i]):
+			temp := remarkTemperature(groups[i][1:])
+			if groups[i][0] == '1' {
+				dec.MaxTemp6Hour = &temp
+			} else {
+				dec.MinTemp6Hour = &temp
+			}
+		case tGroupPattern.MatchString(groups[i]):
+			match := tGroupPattern.FindStringSubmatch(groups[i])
+			temp := remarkTemperature(match[1])
+			dec.Temperature = &temp
+			if match[2] != "" {
+				dewpoint := remarkTemperature(match[2])
+				dec.Dewpoint = &dewpoint
+			}
+		case tendencyPattern.MatchString(groups[i]):
+			character := int(groups[i][1] - '0')
+			tenths, _ := strconv.Atoi(groups[i][2:])
+			change := float64(tenths) / 10
+			if character > 4 {
+				change = -change
+			}
+			dec.PressureTendency = &PressureTendencyDec{
+				Character: character,
+				Desc:      tendencyCharacters[character],
+				ChangeHpa: change,
+			}
+		}
+	}
+	return dec
+}
+
+// remarkTemperature decodes a remark temperature such as "0142" or "1021": a sign digit, 1 for
+// below zero, followed by the temperature in tenths of a degree Celsius.
+func remarkTemperature(group string) float64 {
+	tenths, _ := strconv.Atoi(group[1:])
+	if group[0] == '1' {
+		tenths = -tenths
+	}
+	return float64(tenths) / 10
+}
+
+// seaLevelPressure decodes the tenths of hPa in an SLP group, restoring the leading 10 or 9 omitted
+// from the report by choosing whichever gives a pressure nearer to standard.
+func seaLevelPressure(tenths string) float64 {
+	value, _ := strconv.Atoi(tenths)
+	if value < 500 {
+		return 1000 + float64(value)/10
+	}
+	return 900 + float64(value)/10
+}
@@ -0,0 +1,151 @@
+package avwx
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestDecodeRemarksTGroup(t *testing.T) {
+	tests := []struct {
+		remarks        string
+		temp, dewpoint *float64
+	}{
+		{"AO2 T01830122", floatPtr(18.3), floatPtr(12.2)},
+		{"AO2 T10171028", floatPtr(-1.7), floatPtr(-2.8)},
+		{"AO2 T0006", floatPtr(0.6), nil},
+		{"AO2 T0183012", nil, nil},
+		{"AO2 SLP167", nil, nil},
+	}
+	for _, tt := range tests {
+		dec := decodeRemarks(tt.remarks)
+		if !equalFloatPtr(dec.Temperature, tt.temp) || !equalFloatPtr(dec.Dewpoint, tt.dewpoint) {
+			t.Errorf("decodeRemarks(%q) temperature, dewpoint = %v, %v, want %v, %v", tt.remarks,
+				formatFloatPtr(dec.Temperature), formatFloatPtr(dec.Dewpoint), formatFloatPtr(tt.temp), formatFloatPtr(tt.dewpoint))
+		}
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return math.Abs(*a-*b) < 1e-9
+}
+
+func formatFloatPtr(f *float64) string {
+	if f == nil {
+		return "nil"
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func TestDecodeRemarksWind(t *testing.T) {
+	tests := []struct {
+		remarks   string
+		peakWind  *PeakWindDec
+		windShift *WindShiftDec
+	}{
+		{"AO2 PK WND 28045/1955 SLP167", &PeakWindDec{DirectionDeg: 280, SpeedKt: 45, Time: "1955"}, nil},
+		{"AO2 PK WND 280105/55", &PeakWindDec{DirectionDeg: 280, SpeedKt: 105, Time: "55"}, nil},
+		{"AO2 WSHFT 1715", nil, &WindShiftDec{Time: "1715"}},
+		{"AO2 WSHFT 30 FROPA", nil, &WindShiftDec{Time: "30", Frontal: true}},
+		{"AO2 PK WND 28045/1955 WSHFT 1715", &PeakWindDec{DirectionDeg: 280, SpeedKt: 45, Time: "1955"}, &WindShiftDec{Time: "1715"}},
+		{"AO2 PK WND", nil, nil},
+		{"AO2 WSHFT", nil, nil},
+	}
+	for _, tt := range tests {
+		dec := decodeRemarks(tt.remarks)
+		if (dec.PeakWind == nil) != (tt.peakWind == nil) || dec.PeakWind != nil && *dec.PeakWind != *tt.peakWind {
+			t.Errorf("decodeRemarks(%q).PeakWind = %+v, want %+v", tt.remarks, dec.PeakWind, tt.peakWind)
+		}
+		if (dec.WindShift == nil) != (tt.windShift == nil) || dec.WindShift != nil && *dec.WindShift != *tt.windShift {
+			t.Errorf("decodeRemarks(%q).WindShift = %+v, want %+v", tt.remarks, dec.WindShift, tt.windShift)
+		}
+	}
+}
+
+func TestDecodeRemarksSeaLevelPressure(t *testing.T) {
+	tests := []struct {
+		remarks string
+		want    float64
+	}{
+		{"AO2 SLP125", 1012.5},
+		{"AO2 SLP982", 998.2},
+		{"AO2 SLP000", 1000.0},
+		{"AO2 SLP499", 1049.9},
+		{"AO2 SLP500", 950.0},
+		{"AO2 SLPNO", 0},
+		{"AO2", 0},
+	}
+	for _, tt := range tests {
+		if got := decodeRemarks(tt.remarks).SeaLevelPressureHpa; math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("decodeRemarks(%q).SeaLevelPressureHpa = %v, want %v", tt.remarks, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeRemarksPrecipitation(t *testing.T) {
+	tests := []struct {
+		remarks                     string
+		hourly, threeOrSix, hours24 *float64
+	}{
+		{"AO2 P0012", floatPtr(0.12), nil, nil},
+		{"AO2 P0000", floatPtr(0), nil, nil},
+		{"AO2 60000", nil, floatPtr(0), nil},
+		{"AO2 P0012 60021 70125", floatPtr(0.12), floatPtr(0.21), floatPtr(1.25)},
+		{"AO2 P001 6002", nil, nil, nil},
+		{"AO2", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		dec := decodeRemarks(tt.remarks)
+		if !equalFloatPtr(dec.PrecipHourly, tt.hourly) || !equalFloatPtr(dec.Precip3Or6Hour, tt.threeOrSix) || !equalFloatPtr(dec.Precip24Hour, tt.hours24) {
+			t.Errorf("decodeRemarks(%q) precipitation = %s, %s, %s, want %s, %s, %s", tt.remarks,
+				formatFloatPtr(dec.PrecipHourly), formatFloatPtr(dec.Precip3Or6Hour), formatFloatPtr(dec.Precip24Hour),
+				formatFloatPtr(tt.hourly), formatFloatPtr(tt.threeOrSix), formatFloatPtr(tt.hours24))
+		}
+	}
+}
+
+func TestDecodeRemarksTemp6Hour(t *testing.T) {
+	tests := []struct {
+		remarks  string
+		max, min *float64
+	}{
+		{"AO2 10142 21021", floatPtr(14.2), floatPtr(-2.1)},
+		{"AO2 11005 20000", floatPtr(-0.5), floatPtr(0)},
+		{"AO2 10250", floatPtr(25), nil},
+		{"AO2 1014 22021", nil, nil},
+		{"AO2", nil, nil},
+	}
+	for _, tt := range tests {
+		dec := decodeRemarks(tt.remarks)
+		if !equalFloatPtr(dec.MaxTemp6Hour, tt.max) || !equalFloatPtr(dec.MinTemp6Hour, tt.min) {
+			t.Errorf("decodeRemarks(%q) max, min = %s, %s, want %s, %s", tt.remarks,
+				formatFloatPtr(dec.MaxTemp6Hour), formatFloatPtr(dec.MinTemp6Hour), formatFloatPtr(tt.max), formatFloatPtr(tt.min))
+		}
+	}
+}
+
+func TestDecodeRemarksPressureTendency(t *testing.T) {
+	tests := []struct {
+		remarks string
+		want    *PressureTendencyDec
+	}{
+		{"AO2 52032", &PressureTendencyDec{Character: 2, Desc: "INCREASING", ChangeHpa: 3.2}},
+		{"AO2 58010", &PressureTendencyDec{Character: 8, Desc: "STEADY OR INCREASING, THEN DECREASING", ChangeHpa: -1.0}},
+		{"AO2 54000", &PressureTendencyDec{Character: 4, Desc: "STEADY", ChangeHpa: 0}},
+		{"AO2 59010", nil},
+		{"AO2", nil},
+	}
+	for _, tt := range tests {
+		got := decodeRemarks(tt.remarks).PressureTendency
+		if (got == nil) != (tt.want == nil) || got != nil && (got.Character != tt.want.Character || got.Desc != tt.want.Desc || math.Abs(got.ChangeHpa-tt.want.ChangeHpa) > 1e-9) {
+			t.Errorf("decodeRemarks(%q).PressureTendency = %+v, want %+v", tt.remarks, got, tt.want)
+		}
+	}
+}
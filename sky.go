@@ -0,0 +1,80 @@
+package avwx
+
+// LowestLayer returns the lowest reported cloud layer of any coverage.
+// ok is false when no layer with a height is reported, as with clear skies.
+func (m *Metar) LowestLayer() (layer *CloudLayerDec, ok bool) {
+	for i := range m.CloudLayersDec {
+		if m.CloudLayersDec[i].HeightFt == "" {
+			continue
+		}
+		if layer == nil || m.CloudLayersDec[i].HeightFtInt < layer.HeightFtInt {
+			layer = &m.CloudLayersDec[i]
+		}
+	}
+	return layer, layer != nil
+}
+
+// Ceiling returns the lowest broken or overcast layer, or the vertical visibility into an obscured sky.
+// ok is false when no such layer is reported.
+func (m *Metar) Ceiling() (layer *CloudLayerDec, ok bool) {
+	for i := range m.CloudLayersDec {
+		if m.CloudLayersDec[i].HeightFt == "" || !isCoverage(m.CloudLayersDec[i].Coverage, "BKN", "OVC", "VV") {
+			continue
+		}
+		if layer == nil || m.CloudLayersDec[i].HeightFtInt < layer.HeightFtInt {
+			layer = &m.CloudLayersDec[i]
+		}
+	}
+	return layer, layer != nil
+}
+
+// Sky conditions returned by SkyCondition.
+const (
+	SkyClear           = "CLEAR"
+	SkyObscured        = "OBSCURED"
+	SkyCeiling         = "CEILING"
+	SkyScatteredBroken = "SCATTERED/BROKEN"
+	SkyUnknown         = "UNKNOWN"
+)
+
+// SkyCondition categorizes the reported sky: OBSCURED when a vertical visibility is reported, CEILING
+// when a broken or overcast layer is, SCATTERED/BROKEN for lesser cloud and CLEAR when no cloud is
+// reported, including SKC and the CLR of automated stations. Layers reported as slashes by an automated
+// station give UNKNOWN unless a ceiling is known.
+func (m *Metar) SkyCondition() string {
+	unknown := false
+	scattered := false
+	ceiling := false
+	for _, layer := range m.CloudLayersDec {
+		switch {
+		case isCoverage(layer.Coverage, "VV"):
+			return SkyObscured
+		case isCoverage(layer.Coverage, "BKN", "OVC"):
+			ceiling = true
+		case isCoverage(layer.Coverage, "FEW", "SCT"):
+			scattered = true
+		case layer.Coverage == "":
+			unknown = true
+		}
+	}
+
+	switch {
+	case ceiling:
+		return SkyCeiling
+	case unknown:
+		return SkyUnknown
+	case scattered:
+		return SkyScatteredBroken
+	}
+	return SkyClear
+}
+
+// isCoverage reports whether a decoded coverage is the description of one of the given coverage codes.
+func isCoverage(desc string, codes ...string) bool {
+	for _, code := range codes {
+		if codeDesc, _ := LookupCoverage(code); desc != "" && desc == codeDesc {
+			return true
+		}
+	}
+	return false
+}
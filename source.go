@@ -0,0 +1,97 @@
+package avwx
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Source fetches the current METAR for a station on behalf of a Client. It returns the report along
+// with the response body when the source delivers the API's JSON.
+type Source interface {
+	FetchMetar(ctx context.Context, c *Client, station string, opts []string) (*Metar, []byte, error)
+}
+
+// WithSource sets where the client fetches current reports from. AVWXSource is used by default.
+func WithSource(source Source) Option {
+	return func(c *Client) {
+		c.source = source
+	}
+}
+
+// AVWXSource fetches reports from the avwx API at the client's base URL, requesting the given options.
+type AVWXSource struct{}
+
+// FetchMetar fetches and decodes the station's current METAR from the avwx API.
+func (AVWXSource) FetchMetar(ctx context.Context, c *Client, station string, opts []string) (*Metar, []byte, error) {
+	return c.fetchMetar(ctx, station, opts)
+}
+
+// NOAASource fetches raw reports from the Aviation Weather Center and decodes them locally with the
+// client's decoder, unless the client was created WithoutDecoding. Only the fields found in the raw
+// report are filled in, so there is no summary, speech or station info, and the report options are
+// ignored.
+type NOAASource struct {
+	// URL is the METAR endpoint. When empty, the endpoint used by FetchMetarAt is used.
+	URL string
+}
+
+// FetchMetar fetches the station's latest raw METAR from the Aviation Weather Center and decodes it.
+func (s NOAASource) FetchMetar(ctx context.Context, c *Client, station string, opts []string) (*Metar, []byte, error) {
+	endpoint := s.URL
+	if endpoint == "" {
+		endpoint = c.historyURL
+	}
+	query := url.Values{}
+	query.Set("ids", station)
+	query.Set("format", "raw")
+
+	resp, err := c.get(ctx, station, endpoint+"?"+query.Encode())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("Query failed: %s", resp.Status)
+	}
+
+	// Reports are listed newest first.
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if metar, err := c.parseMetar(scanner.Text()); err == nil {
+			return metar, nil, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, wrapTimeout(err)
+	}
+	return nil, nil, ErrNoReport
+}
+
+// MultiSource tries each source in order, returning the first report fetched successfully.
+// When every source fails, the error joins the errors of each.
+type MultiSource []Source
+
+// FetchMetar fetches the station's current METAR from the first source which succeeds.
+func (s MultiSource) FetchMetar(ctx context.Context, c *Client, station string, opts []string) (*Metar, []byte, error) {
+	var errs []error
+	for _, source := range s {
+		metar, body, err := source.FetchMetar(ctx, c, station, opts)
+		if err == nil {
+			return metar, body, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if len(errs) == 0 {
+		return nil, nil, fmt.Errorf("No sources configured")
+	}
+	return nil, nil, errors.Join(errs...)
+}
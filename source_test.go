@@ -0,0 +1,83 @@
+package avwx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNOAASource(t *testing.T) {
+	when := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute)
+	srv := historyServer(when)
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		temp    string
+		tempC   float64
+		decoded bool
+	}{
+		{"decoded", nil, "-5.0", -5, true},
+		{"without decoding", []Option{WithoutDecoding()}, "M05", 0, false},
+	}
+	for _, tt := range tests {
+		client := NewClient(append(tt.opts, WithSource(NOAASource{URL: srv.URL}))...)
+		metarResp := client.FetchMetar("KSFO")
+		if metarResp.Error != nil {
+			t.Fatalf("%s: FetchMetar error: %v", tt.name, metarResp.Error)
+		}
+		m := metarResp.Metar
+		if m.Time != when.Format("021504Z") {
+			t.Errorf("%s: Time = %q, want the newest report", tt.name, m.Time)
+		}
+		if m.Temperature != tt.temp || m.TemperatureC != tt.tempC || (m.CloudLayersDec != nil) != tt.decoded {
+			t.Errorf("%s: Temperature, TemperatureC, CloudLayersDec = %q, %v, %+v", tt.name, m.Temperature, m.TemperatureC, m.CloudLayersDec)
+		}
+	}
+}
+
+func TestNOAASourceNoReport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	metarResp := NewClient(WithSource(NOAASource{URL: srv.URL})).FetchMetar("KSFO")
+	if !errors.Is(metarResp.Error, ErrNoReport) {
+		t.Errorf("FetchMetar error = %v, want ErrNoReport", metarResp.Error)
+	}
+}
+
+func TestMultiSource(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	when := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute)
+	fallback := historyServer(when)
+	defer fallback.Close()
+
+	client := NewClient(WithBaseURL(failing.URL+"/"), WithSource(MultiSource{AVWXSource{}, NOAASource{URL: fallback.URL}}))
+	metarResp := client.FetchMetar("KSFO")
+	if metarResp.Error != nil {
+		t.Fatalf("FetchMetar error: %v", metarResp.Error)
+	}
+	if m := metarResp.Metar; m.Time != when.Format("021504Z") || m.TemperatureC != -5 {
+		t.Errorf("FetchMetar returned %q, want the fallback's newest report", m.RawReport)
+	}
+
+	client = NewClient(WithBaseURL(failing.URL+"/"), WithSource(MultiSource{AVWXSource{}, NOAASource{URL: failing.URL}}))
+	metarResp = client.FetchMetar("KSFO")
+	if metarResp.Error == nil {
+		t.Fatal("FetchMetar succeeded with every source failing")
+	}
+	if n := strings.Count(metarResp.Error.Error(), "503"); n != 2 {
+		t.Errorf("FetchMetar error %q reports %d source failures, want 2", metarResp.Error, n)
+	}
+
+	if metarResp := NewClient(WithSource(MultiSource{})).FetchMetar("KSFO"); metarResp.Error == nil {
+		t.Error("FetchMetar succeeded with no sources")
+	}
+}
@@ -0,0 +1,134 @@
+package avwx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const stationURL = "https://avwx.rest/api/station/"
+
+// Station describes a reporting station as returned by the station endpoint.
+type Station struct {
+	ICAO        string  `json:"icao"`
+	IATA        string  `json:"iata"`
+	Name        string  `json:"name"`
+	City        string  `json:"city"`
+	State       string  `json:"state"`
+	Country     string  `json:"country"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	ElevationFt float64 `json:"elevation_ft"`
+}
+
+// stationCache holds station information keyed by the code it was requested with.
+type stationCache struct {
+	mu       sync.Mutex
+	stations map[string]Station
+}
+
+func (sc *stationCache) get(code string) (Station, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	station, ok := sc.stations[code]
+	return station, ok
+}
+
+func (sc *stationCache) put(code string, station Station) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.stations == nil {
+		sc.stations = make(map[string]Station)
+	}
+	sc.stations[code] = station
+}
+
+// WithStationCoordinates makes FetchMetar fill in the station coordinates and elevation on LocationInfo.
+// The info block returned with a METAR does not carry them, so they are looked up from the station
+// endpoint, once per station for the lifetime of the client. A failed lookup is reported in
+// MetarResponse.LocationError without discarding the report.
+func WithStationCoordinates() Option {
+	return func(c *Client) {
+		c.stationCoordinates = true
+	}
+}
+
+// WithStationURL sets the station endpoint used to look up station information.
+func WithStationURL(endpoint string) Option {
+	return func(c *Client) {
+		c.stationURL = endpoint
+	}
+}
+
+// FetchStation fetches information for the station with the given ICAO or IATA code using DefaultClient.
+func FetchStation(code string) (*Station, error) {
+	return DefaultClient.FetchStation(code)
+}
+
+// ResolveICAO returns the ICAO code for an IATA code such as "SFO" using DefaultClient.
+func ResolveICAO(iata string) (string, error) {
+	return DefaultClient.ResolveICAO(iata)
+}
+
+// FetchStation fetches information for the station with the given ICAO or IATA code.
+func (c *Client) FetchStation(code string) (*Station, error) {
+	resp, err := c.get(context.Background(), code, c.stationURL+strings.ToUpper(code))
+	if err != nil {
+		return nil, newFetchError(code, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newFetchError(code, statusError(resp))
+	}
+
+	var station Station
+	if err := json.NewDecoder(resp.Body).Decode(&station); err != nil {
+		return nil, newFetchError(code, wrapTimeout(err))
+	}
+	return &station, nil
+}
+
+// ResolveICAO returns the ICAO code for an IATA code such as "SFO" using the station endpoint.
+// Resolved codes are cached for the lifetime of the client.
+func (c *Client) ResolveICAO(iata string) (string, error) {
+	station, err := c.lookupStation(iata)
+	if err != nil {
+		return "", err
+	}
+	if station.ICAO == "" {
+		return "", fmt.Errorf("Unable to resolve airport code: %s", iata)
+	}
+	return station.ICAO, nil
+}
+
+// lookupStation returns station information, fetching it only if it is not already cached.
+func (c *Client) lookupStation(code string) (Station, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if station, ok := c.stations.get(code); ok {
+		return station, nil
+	}
+
+	station, err := c.FetchStation(code)
+	if err != nil {
+		return Station{}, err
+	}
+	c.stations.put(code, *station)
+	return *station, nil
+}
+
+// addCoordinates copies the station coordinates and elevation onto the location info.
+func (c *Client) addCoordinates(metar *Metar) error {
+	station, err := c.lookupStation(metar.Station)
+	if err != nil {
+		return err
+	}
+	metar.LocationInfo.Latitude = station.Latitude
+	metar.LocationInfo.Longitude = station.Longitude
+	metar.LocationInfo.ElevationFt = station.ElevationFt
+	return nil
+}
@@ -0,0 +1,118 @@
+package avwx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// stationServer stubs the station endpoint for SFO and KSFO, counting the requests made.
+func stationServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch strings.TrimPrefix(r.URL.Path, "/") {
+		case "SFO", "KSFO":
+			fmt.Fprint(w, `{"icao": "KSFO", "iata": "SFO", "name": "San Francisco International Airport",
+				"latitude": 37.619, "longitude": -122.375, "elevation_ft": 13}`)
+		case "NIL":
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "XXX is not a valid ICAO or station code"}`)
+		}
+	}))
+}
+
+func TestResolveICAO(t *testing.T) {
+	var requests int32
+	srv := stationServer(&requests)
+	defer srv.Close()
+	client := NewClient(WithStationURL(srv.URL + "/"))
+
+	for i := 0; i < 3; i++ {
+		icao, err := client.ResolveICAO("sfo")
+		if err != nil || icao != "KSFO" {
+			t.Fatalf("ResolveICAO(\"sfo\") = %q, %v, want \"KSFO\"", icao, err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("3 lookups made %d requests, want 1", n)
+	}
+
+	if _, err := client.ResolveICAO("XXX"); !errors.Is(err, ErrStationNotFound) {
+		t.Errorf("ResolveICAO(\"XXX\") error = %v, want ErrStationNotFound", err)
+	}
+	if _, err := client.ResolveICAO("NIL"); err == nil || !strings.Contains(err.Error(), "Unable to resolve") {
+		t.Errorf("ResolveICAO(\"NIL\") error = %v, want an unresolved code error", err)
+	}
+}
+
+func TestStationCoordinates(t *testing.T) {
+	var requests int32
+	stations := stationServer(&requests)
+	defer stations.Close()
+	metars := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer metars.Close()
+
+	client := NewClient(WithBaseURL(metars.URL+"/"), WithStationURL(stations.URL+"/"), WithStationCoordinates())
+	metarResp := client.FetchMetar("KSFO")
+	if metarResp.Error != nil || metarResp.LocationError != nil {
+		t.Fatalf("FetchMetar errors: %v, %v", metarResp.Error, metarResp.LocationError)
+	}
+	info := metarResp.Metar.LocationInfo
+	if info.Latitude != 37.619 || info.Longitude != -122.375 || info.ElevationFt != 13 {
+		t.Errorf("LocationInfo = %+v, want the station coordinates", info)
+	}
+
+	// Coordinates survive a round trip through JSON for consumers serializing responses.
+	data, err := json.Marshal(metarResp.Metar)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var decoded Metar
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if decoded.LocationInfo != info {
+		t.Errorf("LocationInfo after round trip = %+v, want %+v", decoded.LocationInfo, info)
+	}
+}
+
+func TestStationCoordinatesLookupFails(t *testing.T) {
+	stations := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer stations.Close()
+	metars := httptest.NewServer(http.HandlerFunc(metarHandler))
+	defer metars.Close()
+
+	client := NewClient(WithBaseURL(metars.URL+"/"), WithStationURL(stations.URL+"/"), WithStationCoordinates())
+	metarResp := client.FetchMetar("KSFO")
+	var fetchErr *FetchError
+	if !errors.As(metarResp.LocationError, &fetchErr) {
+		t.Errorf("LocationError = %v, want a FetchError", metarResp.LocationError)
+	}
+	if metarResp.Error != nil || metarResp.Metar.Station != "KSFO" {
+		t.Errorf("FetchMetar = %v, %q, want the report without error", metarResp.Error, metarResp.Metar.Station)
+	}
+	if metar, err := client.FetchMetarDirect("KSFO"); err != nil || metar.Station != "KSFO" {
+		t.Errorf("FetchMetarDirect = %v, %v, want the report", metar, err)
+	}
+}
+
+func TestLocationInfoUnmarshal(t *testing.T) {
+	var info LocationInfo
+	data := `{"City": "San Francisco", "Latitude": "37.619", "Longitude": -122.375, "ElevationFt": ""}`
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	want := LocationInfo{City: "San Francisco", Latitude: 37.619, Longitude: -122.375}
+	if info != want {
+		t.Errorf("LocationInfo = %+v, want %+v", info, want)
+	}
+}
@@ -0,0 +1,60 @@
+package avwx
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// sunriseElevation is the solar elevation in degrees at sunrise and sunset, allowing for refraction
+// and the radius of the sun's disc.
+const sunriseElevation = -0.833
+
+// j2000 is the epoch from which solar positions are computed.
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// IsDaytime reports whether the sun was up at the station when the report was observed, using the
+// coordinates in LocationInfo. The observation time is resolved against the current time.
+// An error is returned when the time or the station coordinates are not known.
+func (m *Metar) IsDaytime() (bool, error) {
+	if m.LocationInfo.Latitude == 0 && m.LocationInfo.Longitude == 0 {
+		return false, fmt.Errorf("Station coordinates unknown: %s", m.Station)
+	}
+	return m.DaylightAt(m.LocationInfo.Latitude, m.LocationInfo.Longitude)
+}
+
+// DaylightAt reports whether the sun was up at the given coordinates, in decimal degrees, when the
+// report was observed. It is decided from the sun's elevation rather than sunrise and sunset times,
+// so polar day and polar night need no special handling.
+func (m *Metar) DaylightAt(lat, lon float64) (bool, error) {
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return false, fmt.Errorf("Invalid coordinates: %g, %g", lat, lon)
+	}
+	observed, err := m.ObservationTime(time.Now())
+	if err != nil {
+		return false, err
+	}
+	return solarElevation(observed, lat, lon) > sunriseElevation, nil
+}
+
+// solarElevation approximates the sun's elevation in degrees above the horizon at a point given in
+// decimal degrees. The approximation is good to within a few hundredths of a degree this century.
+func solarElevation(t time.Time, lat, lon float64) float64 {
+	const toRadians = math.Pi / 180
+	d := t.Sub(j2000).Hours() / 24
+
+	meanAnomaly := (357.529 + 0.98560028*d) * toRadians
+	meanLongitude := 280.459 + 0.98564736*d
+	eclipticLongitude := (meanLongitude + 1.915*math.Sin(meanAnomaly) + 0.020*math.Sin(2*meanAnomaly)) * toRadians
+	obliquity := (23.439 - 0.00000036*d) * toRadians
+
+	rightAscension := math.Atan2(math.Cos(obliquity)*math.Sin(eclipticLongitude), math.Cos(eclipticLongitude))
+	declination := math.Asin(math.Sin(obliquity) * math.Sin(eclipticLongitude))
+	siderealTime := (280.46061837 + 360.98564736629*d) * toRadians
+	hourAngle := siderealTime + lon*toRadians - rightAscension
+
+	latitude := lat * toRadians
+	elevation := math.Asin(math.Sin(latitude)*math.Sin(declination) +
+		math.Cos(latitude)*math.Cos(declination)*math.Cos(hourAngle))
+	return elevation / toRadians
+}
@@ -0,0 +1,52 @@
+package avwx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDaytime(t *testing.T) {
+	// Singapore is on the equator, so 05:00Z, local noon, is day and 17:00Z is night
+	// whatever the date the observation resolves to.
+	info := LocationInfo{Latitude: 1.35, Longitude: 103.99}
+	tests := []struct {
+		time string
+		want bool
+	}{
+		{"150500Z", true},
+		{"151700Z", false},
+	}
+	for _, tt := range tests {
+		m := Metar{Station: "WSSS", Time: tt.time, LocationInfo: info}
+		if got, err := m.IsDaytime(); err != nil || got != tt.want {
+			t.Errorf("IsDaytime() at %s = %v, %v, want %v", tt.time, got, err, tt.want)
+		}
+	}
+
+	if _, err := (&Metar{Station: "WSSS", Time: "150500Z"}).IsDaytime(); err == nil {
+		t.Error("IsDaytime() without coordinates succeeded")
+	}
+	if _, err := (&Metar{Station: "WSSS", Time: "150500Z"}).DaylightAt(91, 0); err == nil {
+		t.Error("DaylightAt(91, 0) succeeded")
+	}
+	if _, err := (&Metar{Station: "WSSS", Time: "bad"}).DaylightAt(1.35, 103.99); err == nil {
+		t.Error("DaylightAt with an invalid time succeeded")
+	}
+}
+
+func TestSolarElevationPolar(t *testing.T) {
+	// Tromsø has midnight sun at the June solstice and polar night at the December solstice.
+	const lat, lon = 69.65, 18.96
+	tests := []struct {
+		when time.Time
+		day  bool
+	}{
+		{time.Date(2024, time.June, 21, 23, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, time.December, 21, 11, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if elevation := solarElevation(tt.when, lat, lon); (elevation > sunriseElevation) != tt.day {
+			t.Errorf("solarElevation(%v) = %.2f°, want daylight %v", tt.when, elevation, tt.day)
+		}
+	}
+}
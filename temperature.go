@@ -0,0 +1,44 @@
+package avwx
+
+import "fmt"
+
+// TemperatureK returns the temperature in Kelvin, computed from the decoded Celsius value.
+// An error is returned when the temperature is missing or was not decoded.
+func (m *Metar) TemperatureK() (float64, error) {
+	if m.TemperatureMissing || !m.hasTemperature() {
+		return 0, fmt.Errorf("Temperature not reported")
+	}
+	return cToK(m.TemperatureC), nil
+}
+
+// DewpointK returns the dewpoint in Kelvin, computed from the decoded Celsius value.
+// An error is returned when the dewpoint is missing or was not decoded.
+func (m *Metar) DewpointK() (float64, error) {
+	if m.DewpointMissing || !m.hasDewpoint() {
+		return 0, fmt.Errorf("Dewpoint not reported")
+	}
+	return cToK(m.DewpointC), nil
+}
+
+// TemperatureSteadyC is the change in Celsius within which TemperatureTrend reports a steady temperature.
+const TemperatureSteadyC = 0.5
+
+// TemperatureTrend compares the temperature with a previous report, returning the change in Celsius
+// and "rising", "falling" or "steady" when it changed by no more than TemperatureSteadyC.
+func (m *Metar) TemperatureTrend(previous *Metar) (float64, string, error) {
+	if previous == nil || !previous.hasTemperature() {
+		return 0, "", fmt.Errorf("Previous temperature not reported")
+	}
+	if !m.hasTemperature() {
+		return 0, "", fmt.Errorf("Temperature not reported")
+	}
+
+	delta := m.TemperatureC - previous.TemperatureC
+	switch {
+	case delta > TemperatureSteadyC:
+		return delta, "rising", nil
+	case delta < -TemperatureSteadyC:
+		return delta, "falling", nil
+	}
+	return delta, "steady", nil
+}
@@ -0,0 +1,51 @@
+package avwx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTemperatureK(t *testing.T) {
+	tests := []struct {
+		name    string
+		decoder Decoder
+	}{
+		{"default", Decoder{}},
+		{"Fahrenheit only", Decoder{TemperatureUnit: UnitFahrenheit}},
+		{"Kelvin only", Decoder{TemperatureUnit: UnitKelvin}},
+		{"whole degrees", Decoder{TemperaturePrecision: Precision(0)}},
+	}
+	for _, tt := range tests {
+		m, err := tt.decoder.ParseMetar("KSFO 221756Z 28010KT 10SM FEW008 18/M05 A3002")
+		if err != nil {
+			t.Fatalf("ParseMetar error: %v", err)
+		}
+		if k, err := m.TemperatureK(); err != nil || math.Abs(k-291.15) > 1e-9 {
+			t.Errorf("%s: TemperatureK() = %v, %v, want 291.15", tt.name, k, err)
+		}
+		if k, err := m.DewpointK(); err != nil || math.Abs(k-268.15) > 1e-9 {
+			t.Errorf("%s: DewpointK() = %v, %v, want 268.15", tt.name, k, err)
+		}
+	}
+
+	// The T group's tenths are kept even when the string fields are rounded to whole degrees.
+	d := Decoder{TemperaturePrecision: Precision(0)}
+	m, err := d.ParseMetar("KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002 RMK AO2 T01830122")
+	if err != nil {
+		t.Fatalf("ParseMetar error: %v", err)
+	}
+	if k, err := m.TemperatureK(); err != nil || math.Abs(k-291.45) > 1e-9 {
+		t.Errorf("TemperatureK() from a T group = %v, %v, want 291.45", k, err)
+	}
+
+	m, err = ParseMetar("KSFO 221756Z 28010KT 10SM FEW008 ///// A3002")
+	if err != nil {
+		t.Fatalf("ParseMetar error: %v", err)
+	}
+	if k, err := m.TemperatureK(); err == nil {
+		t.Errorf("TemperatureK() of a missing temperature = %v, want error", k)
+	}
+	if k, err := m.DewpointK(); err == nil {
+		t.Errorf("DewpointK() of a missing dewpoint = %v, want error", k)
+	}
+}
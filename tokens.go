@@ -0,0 +1,98 @@
+package avwx
+
+import "strings"
+
+// TokenCategory classifies a group of a raw report.
+type TokenCategory string
+
+// Token categories returned by ClassifiedTokens.
+const (
+	TokenModifier      TokenCategory = "modifier"
+	TokenWind          TokenCategory = "wind"
+	TokenWindVariation TokenCategory = "wind-variation"
+	TokenVisibility    TokenCategory = "visibility"
+	TokenRVR           TokenCategory = "rvr"
+	TokenWeather       TokenCategory = "weather"
+	TokenCloud         TokenCategory = "cloud"
+	TokenTemperature   TokenCategory = "temperature"
+	TokenAltimeter     TokenCategory = "altimeter"
+	TokenTrend         TokenCategory = "trend"
+	TokenRemark        TokenCategory = "remark"
+	TokenUnknown       TokenCategory = "unknown"
+)
+
+// Token is a group of a raw report with its category.
+type Token struct {
+	Text     string
+	Category TokenCategory
+}
+
+// Tokens splits the raw report into its whitespace separated groups, leaving out the report type,
+// station and time.
+func (m *Metar) Tokens() []string {
+	fields := strings.Fields(m.RawReport)
+	if len(fields) > 0 && (fields[0] == "METAR" || fields[0] == "SPECI") {
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && stationPattern.MatchString(fields[0]) {
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && timePattern.MatchString(fields[0]) {
+		fields = fields[1:]
+	}
+	return fields
+}
+
+// ClassifiedTokens returns the groups of Tokens along with their category, such as wind, visibility
+// or cloud. Everything from the RMK group onwards is a remark and everything from a trend keyword such
+// as TEMPO onwards, up to any remarks, is part of the trend.
+func (m *Metar) ClassifiedTokens() []Token {
+	fields := m.Tokens()
+	tokens := make([]Token, 0, len(fields))
+	var section TokenCategory
+	for i, field := range fields {
+		switch {
+		case field == "RMK":
+			section = TokenRemark
+		case trendTypes[field] && section == "":
+			section = TokenTrend
+		}
+
+		category := section
+		if category == "" {
+			category = classifyToken(field, fields[i+1:])
+		}
+		tokens = append(tokens, Token{Text: field, Category: category})
+	}
+	return tokens
+}
+
+// classifyToken categorizes a group of a report's body.
+func classifyToken(field string, rest []string) TokenCategory {
+	switch {
+	case field == "AUTO" || field == "COR" || field == "$" ||
+		len(field) == 3 && strings.HasPrefix(field, "CC"):
+		return TokenModifier
+	case windPattern.MatchString(field):
+		return TokenWind
+	case windVarPattern.MatchString(field):
+		return TokenWindVariation
+	case visibilityPattern.MatchString(field) || visFractionPattern.MatchString(field) ||
+		visMetersPattern.MatchString(field) || visDirectionalPattern.MatchString(field) || field == "CAVOK":
+		return TokenVisibility
+	case visWholePattern.MatchString(field) && len(rest) > 0 && visFractionPattern.MatchString(rest[0]):
+		// The whole number of a visibility such as "1 1/2SM".
+		return TokenVisibility
+	case rvrPattern.MatchString(field):
+		return TokenRVR
+	case isCloudGroup(field) || field == "SKC" || field == "CLR" || field == "NSC" || field == "NCD":
+		return TokenCloud
+	case tempPattern.MatchString(field):
+		return TokenTemperature
+	case altimeterPattern.MatchString(field):
+		return TokenAltimeter
+	case isWeatherGroup(field) || len(field) > 2 && strings.HasPrefix(field, "RE") && isWeatherGroup(field[2:]):
+		return TokenWeather
+	}
+	return TokenUnknown
+}
@@ -0,0 +1,39 @@
+package avwx
+
+// Translations holds the API's own English translation of a report, requested with the
+// "translate" report option.
+type Translations struct {
+	Altimeter   string
+	Clouds      string
+	Dewpoint    string
+	Other       string
+	Temperature string
+	Visibility  string
+	Wind        string
+	Remarks     map[string]string
+}
+
+// WithTranslations requests the API's translation block with each METAR, filling in Metar.Translations.
+// It adds to the report options, so it should follow WithReportOptions when both are given.
+func WithTranslations() Option {
+	return func(c *Client) {
+		for _, opt := range c.options {
+			if opt == "translate" {
+				return
+			}
+		}
+		c.options = append(c.options[:len(c.options):len(c.options)], "translate")
+	}
+}
+
+// clone returns a copy of the translations sharing no map with the original.
+func (t Translations) clone() Translations {
+	if t.Remarks != nil {
+		remarks := make(map[string]string, len(t.Remarks))
+		for k, v := range t.Remarks {
+			remarks[k] = v
+		}
+		t.Remarks = remarks
+	}
+	return t
+}
@@ -0,0 +1,61 @@
+package avwx
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testTranslateJSON = `{
+	"Raw-Report": "KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002 RMK AO2",
+	"Station": "KSFO",
+	"Translations": {
+		"Altimeter": "30.02 inHg (1017 hPa)",
+		"Clouds": "Few clouds at 800ft",
+		"Dewpoint": "12°C (54°F)",
+		"Other": "",
+		"Temperature": "18°C (64°F)",
+		"Visibility": "10sm (16km)",
+		"Wind": "W-280 at 10kt",
+		"Remarks": {"AO2": "Automated with precipitation sensor"}
+	}
+}`
+
+func TestWithTranslations(t *testing.T) {
+	var options string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		options = r.URL.Query().Get("options")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, testTranslateJSON)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		opts []Option
+		want string
+	}{
+		{[]Option{WithTranslations()}, defaultOptions + ",translate"},
+		{nil, defaultOptions},
+		{[]Option{WithReportOptions("info"), WithTranslations()}, "info,translate"},
+		{[]Option{WithReportOptions("translate"), WithTranslations()}, "translate"},
+		{[]Option{WithReportOptions(), WithTranslations(), WithTranslations()}, "translate"},
+	}
+	for _, tt := range tests {
+		metarResp := NewClient(append(tt.opts, WithBaseURL(srv.URL+"/"))...).FetchMetar("KSFO")
+		if metarResp.Error != nil {
+			t.Fatalf("FetchMetar error: %v", metarResp.Error)
+		}
+		if options != tt.want {
+			t.Errorf("options = %q, want %q", options, tt.want)
+		}
+		if tt.opts == nil {
+			continue
+		}
+		tr := metarResp.Metar.Translations
+		if tr.Wind != "W-280 at 10kt" || tr.Clouds != "Few clouds at 800ft" || tr.Altimeter != "30.02 inHg (1017 hPa)" ||
+			tr.Temperature != "18°C (64°F)" || tr.Visibility != "10sm (16km)" || tr.Remarks["AO2"] != "Automated with precipitation sensor" {
+			t.Errorf("Translations = %+v", tr)
+		}
+	}
+}
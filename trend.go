@@ -0,0 +1,56 @@
+package avwx
+
+import (
+	"regexp"
+	"strings"
+)
+
+var trendTypes = map[string]bool{
+	"NOSIG": true,
+	"TEMPO": true,
+	"BECMG": true,
+}
+
+var trendTimePattern = regexp.MustCompile(`^(FM|TL|AT)\d{4}$`)
+
+// TrendDec is a short-term forecast appended to a report, such as "TEMPO SHRA" or "BECMG FEW020".
+type TrendDec struct {
+	Type           string
+	Time           string
+	Wind           string
+	Visibility     string
+	ConditionsDec  []ConditionDec
+	CloudLayersDec []CloudLayerDec
+}
+
+// decodeTrends decodes the NOSIG, TEMPO and BECMG groups at the end of a report's body.
+func decodeTrends(groups []string) []TrendDec {
+	var trends []TrendDec
+	var trend *TrendDec
+	for _, group := range groups {
+		if trendTypes[group] {
+			trends = append(trends, TrendDec{Type: group})
+			trend = &trends[len(trends)-1]
+			continue
+		}
+		if trend == nil {
+			continue
+		}
+
+		switch {
+		case trendTimePattern.MatchString(group):
+			trend.Time = strings.TrimSpace(trend.Time + " " + group)
+		case windPattern.MatchString(group):
+			trend.Wind = group
+		case visibilityPattern.MatchString(group) || visMetersPattern.MatchString(group) || group == "CAVOK":
+			trend.Visibility = group
+		case isCloudGroup(group):
+			trend.CloudLayersDec = append(trend.CloudLayersDec, decodeCloudLayer(cloudLayer(group)))
+		case group == "NSW":
+			trend.ConditionsDec = append(trend.ConditionsDec, ConditionDec{Desc: "NO SIGNIFICANT WEATHER"})
+		case isWeatherGroup(group):
+			trend.ConditionsDec = append(trend.ConditionsDec, decodeCondition(group))
+		}
+	}
+	return trends
+}
@@ -0,0 +1,75 @@
+package avwx
+
+import "testing"
+
+func TestDecodeTrends(t *testing.T) {
+	tests := []struct {
+		raw         string
+		want        []TrendDec
+		noSigChange bool
+	}{
+		{
+			raw:         "EGLL 221750Z 24015KT 9999 SCT040 12/06 Q1013 NOSIG",
+			want:        []TrendDec{{Type: "NOSIG"}},
+			noSigChange: true,
+		},
+		{
+			raw: "EGLL 221750Z 24015KT 9999 SCT040 12/06 Q1013 TEMPO 4000 SHRA",
+			want: []TrendDec{{
+				Type:          "TEMPO",
+				Visibility:    "4000",
+				ConditionsDec: []ConditionDec{{Descriptor: "SHOWERS", Desc: "RAIN"}},
+			}},
+		},
+		{
+			raw: "EGLL 221750Z 24015KT 9999 SCT040 12/06 Q1013 BECMG FM1830 FEW020 BKN035CB",
+			want: []TrendDec{{
+				Type: "BECMG",
+				Time: "FM1830",
+				CloudLayersDec: []CloudLayerDec{
+					{Coverage: "FEW", HeightFt: "2000", HeightFtInt: 2000},
+					{Coverage: "BROKEN", HeightFt: "3500", HeightFtInt: 3500, Type: "CUMULONIMBUS"},
+				},
+			}},
+		},
+		{
+			raw:  "KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.NoSignificantChange != tt.noSigChange {
+			t.Errorf("%q: NoSignificantChange = %v, want %v", tt.raw, m.NoSignificantChange, tt.noSigChange)
+		}
+		if !equalTrends(m.Trends, tt.want) {
+			t.Errorf("%q: Trends = %+v, want %+v", tt.raw, m.Trends, tt.want)
+		}
+	}
+}
+
+func equalTrends(a, b []TrendDec) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Time != b[i].Time || a[i].Wind != b[i].Wind || a[i].Visibility != b[i].Visibility ||
+			len(a[i].ConditionsDec) != len(b[i].ConditionsDec) || len(a[i].CloudLayersDec) != len(b[i].CloudLayersDec) {
+			return false
+		}
+		for j := range a[i].ConditionsDec {
+			if a[i].ConditionsDec[j] != b[i].ConditionsDec[j] {
+				return false
+			}
+		}
+		for j := range a[i].CloudLayersDec {
+			if a[i].CloudLayersDec[j] != b[i].CloudLayersDec[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
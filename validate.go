@@ -0,0 +1,27 @@
+package avwx
+
+import "fmt"
+
+// Validate checks the decoded values for readings which are implausible and likely corrupt.
+// All problems found are returned; an empty result means the report looks sane.
+func (m *Metar) Validate() []error {
+	var errs []error
+
+	if m.hasTemperature() && m.hasDewpoint() && m.TemperatureC < m.DewpointC {
+		errs = append(errs, fmt.Errorf("Temperature %.1f°C is below dewpoint %.1f°C", m.TemperatureC, m.DewpointC))
+	}
+
+	if m.AltimeterInHg != 0 && (m.AltimeterInHg < 25 || m.AltimeterInHg > 32) {
+		errs = append(errs, fmt.Errorf("Altimeter %.2f is outside 25-32 inHg", m.AltimeterInHg))
+	}
+
+	if m.WindDirection != "" && m.WindDirection != "VRB" && (m.WindDirectionDeg < 0 || m.WindDirectionDeg > 360) {
+		errs = append(errs, fmt.Errorf("Wind direction %d is outside 0-360", m.WindDirectionDeg))
+	}
+
+	if m.VisibilitySM < 0 {
+		errs = append(errs, fmt.Errorf("Visibility %v is negative", m.VisibilitySM))
+	}
+
+	return errs
+}
@@ -0,0 +1,75 @@
+package avwx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		metar   Metar
+		decoder Decoder
+		want    []string
+	}{
+		{
+			name:  "sane",
+			metar: Metar{Temperature: "18", Dewpoint: "12", Altimeter: "3002", WindDirection: "280", WindSpeed: "10", Visibility: "10"},
+		},
+		{
+			name:  "temperature below dewpoint",
+			metar: Metar{Temperature: "10", Dewpoint: "12"},
+			want:  []string{"below dewpoint"},
+		},
+		{
+			name:    "temperature below dewpoint in Fahrenheit only",
+			metar:   Metar{Temperature: "M02", Dewpoint: "01"},
+			decoder: Decoder{TemperatureUnit: UnitFahrenheit},
+			want:    []string{"below dewpoint"},
+		},
+		{
+			name:    "temperature below dewpoint in Kelvin only",
+			metar:   Metar{Temperature: "10", Dewpoint: "12"},
+			decoder: Decoder{TemperatureUnit: UnitKelvin},
+			want:    []string{"below dewpoint"},
+		},
+		{
+			name:  "missing dewpoint",
+			metar: Metar{Temperature: "10", Dewpoint: "//"},
+		},
+		{
+			name:  "altimeter",
+			metar: Metar{Altimeter: "3500"},
+			want:  []string{"Altimeter"},
+		},
+		{
+			name:  "wind direction",
+			metar: Metar{WindDirection: "400", WindSpeed: "10"},
+			want:  []string{"Wind direction"},
+		},
+		{
+			name:  "negative visibility",
+			metar: Metar{Visibility: "-1"},
+			want:  []string{"Visibility"},
+		},
+		{
+			name:  "all problems",
+			metar: Metar{Temperature: "10", Dewpoint: "12", Altimeter: "2000", WindDirection: "370", WindSpeed: "05", Visibility: "-2"},
+			want:  []string{"below dewpoint", "Altimeter", "Wind direction", "Visibility"},
+		},
+	}
+	for _, tt := range tests {
+		metar := tt.metar
+		tt.decoder.decodeMetar(&metar)
+		errs := metar.Validate()
+		if len(errs) != len(tt.want) {
+			t.Errorf("%s: Validate() = %v, want %d problems", tt.name, errs, len(tt.want))
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), tt.want[i]) {
+				t.Errorf("%s: problem %d = %q, want it to mention %q", tt.name, i, err, tt.want[i])
+			}
+		}
+	}
+}
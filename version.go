@@ -0,0 +1,29 @@
+package avwx
+
+import "fmt"
+
+// Version is the version of this package.
+const Version = "0.2.0"
+
+// ClientVersion identifies this package in the User-Agent header of each request.
+const ClientVersion = "avwx-go/" + Version
+
+// FetchError describes a failed fetch, recording the station and package version for support requests.
+type FetchError struct {
+	Station string
+	Version string
+	Err     error
+}
+
+func newFetchError(station string, err error) *FetchError {
+	return &FetchError{Station: station, Version: Version, Err: err}
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("avwx-go/%s %s: %v", e.Version, e.Station, e.Err)
+}
+
+// Unwrap returns the underlying error so errors.Is and errors.As see through a FetchError.
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,53 @@
+package avwx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	if Version == "" {
+		t.Fatal("Version is empty")
+	}
+	if !strings.Contains(ClientVersion, Version) {
+		t.Errorf("ClientVersion %q does not contain Version %q", ClientVersion, Version)
+	}
+}
+
+func TestUserAgentEmbedsVersion(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		metarHandler(w, r)
+	}))
+	defer srv.Close()
+
+	NewClient(WithBaseURL(srv.URL + "/")).FetchMetar("KSFO")
+	if !strings.Contains(userAgent, Version) {
+		t.Errorf("User-Agent %q does not contain Version %q", userAgent, Version)
+	}
+
+	NewClient(WithBaseURL(srv.URL+"/"), WithUserAgent("myapp/1.0")).FetchMetar("KSFO")
+	if userAgent != "myapp/1.0" {
+		t.Errorf("User-Agent = %q, want %q", userAgent, "myapp/1.0")
+	}
+}
+
+func TestFetchErrorIncludesVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := NewClient(WithBaseURL(srv.URL + "/")).FetchMetar("KSFO").Error
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatalf("FetchMetar error = %v, want a FetchError", err)
+	}
+	if fetchErr.Version != Version || !strings.Contains(err.Error(), Version) || fetchErr.Station != "KSFO" {
+		t.Errorf("FetchError = %q, want it to carry the station and Version %q", err, Version)
+	}
+}
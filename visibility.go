@@ -0,0 +1,185 @@
+package avwx
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const metersPerStatuteMile = 1609.344
+
+var (
+	visDirectionalPattern = regexp.MustCompile(`^(\d{4})(N|NE|E|SE|S|SW|W|NW)$`)
+	visRemarkValuePattern = regexp.MustCompile(`^\d+(?:/\d+)?$`)
+)
+
+var compassOctants = map[string]bool{
+	"N": true, "NE": true, "E": true, "SE": true,
+	"S": true, "SW": true, "W": true, "NW": true,
+}
+
+// SecondaryVisibilityDec is a visibility reported besides the prevailing one, such as the minimum
+// visibility "1500SW" or the remark "VIS NE 2 1/2". Direction is empty when none is given.
+type SecondaryVisibilityDec struct {
+	Direction    string
+	Visibility   string
+	VisibilitySM float64
+}
+
+// DefaultLowVisibilitySM is the threshold IsLowVisibility applies when none is given.
+const DefaultLowVisibilitySM = 1.0
+
+// Visibility modifiers reported with the P and M prefixes, and for a visibility reported without
+// either. The modifier is empty when no visibility is reported.
+const (
+	VisibilityGreaterThan = "GREATER_THAN"
+	VisibilityLessThan    = "LESS_THAN"
+	VisibilityExact       = "EXACT"
+)
+
+// ParseVisibilitySM parses a statute mile visibility such as "10SM", "3/4SM" or "1 1/2SM".
+// The SM suffix and any P or M prefix are optional and ignored.
+func ParseVisibilitySM(token string) (float64, error) {
+	value := strings.TrimSuffix(strings.TrimSpace(token), "SM")
+	value, _ = cutMinus(strings.TrimPrefix(value, "P"))
+	if value == "" {
+		return 0, fmt.Errorf("Invalid visibility: %s", token)
+	}
+
+	var total float64
+	for _, part := range strings.Fields(value) {
+		if num, den, ok := strings.Cut(part, "/"); ok {
+			n, errNum := strconv.ParseFloat(num, 64)
+			d, errDen := strconv.ParseFloat(den, 64)
+			if errNum != nil || errDen != nil || d == 0 {
+				return 0, fmt.Errorf("Invalid visibility: %s", token)
+			}
+			total += n / d
+			continue
+		}
+		whole, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid visibility: %s", token)
+		}
+		total += whole
+	}
+	return total, nil
+}
+
+// parseVisibility parses a visibility value as delivered by the API into statute miles.
+// Four digit values are metric visibilities in meters.
+func parseVisibility(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 4 && !strings.Contains(value, "/") {
+		meters, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid visibility: %s", value)
+		}
+		return float64(meters) / metersPerStatuteMile, nil
+	}
+	return ParseVisibilitySM(value)
+}
+
+// VisibilityMeters returns the prevailing visibility in meters. Four digit visibilities are taken
+// as meters, with 9999 ("10km or more") returned as 10000; statute mile visibilities are converted.
+func (m *Metar) VisibilityMeters() (int, error) {
+	if !m.hasVisibility() {
+		return 0, fmt.Errorf("Invalid visibility: %q", m.Visibility)
+	}
+	value := strings.TrimSpace(m.Visibility)
+	if len(value) == 4 && isDigits(value) {
+		meters, _ := strconv.Atoi(value)
+		if meters == 9999 {
+			return 10000, nil
+		}
+		return meters, nil
+	}
+
+	miles, err := ParseVisibilitySM(value)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Round(miles * metersPerStatuteMile)), nil
+}
+
+// IsLowVisibility reports whether visibility is restricted: the reported visibility is below thresholdSM
+// statute miles, or fog or mist is present at the station. A threshold of zero or less uses
+// DefaultLowVisibilitySM.
+func (m *Metar) IsLowVisibility(thresholdSM float64) bool {
+	if thresholdSM <= 0 {
+		thresholdSM = DefaultLowVisibilitySM
+	}
+	if m.hasVisibility() && m.VisibilitySM < thresholdSM {
+		return true
+	}
+
+	for _, condition := range m.Conditions {
+		if strings.HasPrefix(condition, "VC") {
+			continue
+		}
+		for _, code := range conditionCodes(condition) {
+			if code == "FG" || code == "BR" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasVisibility reports whether a visibility was reported.
+func (m *Metar) hasVisibility() bool {
+	return !isMissing(m.Visibility)
+}
+
+// decodeSecondaryVisibility collects the minimum visibility groups following the prevailing visibility
+// in a report's body and the directional visibility remarks.
+func decodeSecondaryVisibility(groups []string, remarks string) []SecondaryVisibilityDec {
+	var visibilities []SecondaryVisibilityDec
+	prevailing := false
+	for _, group := range groups {
+		if trendTypes[group] {
+			break
+		}
+		if match := visDirectionalPattern.FindStringSubmatch(group); match != nil {
+			meters, _ := strconv.Atoi(match[1])
+			visibilities = append(visibilities, SecondaryVisibilityDec{
+				Direction:    match[2],
+				Visibility:   match[1],
+				VisibilitySM: float64(meters) / metersPerStatuteMile,
+			})
+		} else if visMetersPattern.MatchString(group) {
+			if prevailing {
+				meters, _ := strconv.Atoi(group)
+				visibilities = append(visibilities, SecondaryVisibilityDec{
+					Visibility:   group,
+					VisibilitySM: float64(meters) / metersPerStatuteMile,
+				})
+			}
+			prevailing = true
+		} else if visibilityPattern.MatchString(group) || visFractionPattern.MatchString(group) {
+			prevailing = true
+		}
+	}
+
+	fields := strings.Fields(remarks)
+	for i := 0; i+2 < len(fields); i++ {
+		if fields[i] != "VIS" || !compassOctants[fields[i+1]] {
+			continue
+		}
+		var parts []string
+		for j := i + 2; j < len(fields) && visRemarkValuePattern.MatchString(fields[j]); j++ {
+			parts = append(parts, fields[j])
+		}
+		value := strings.Join(parts, " ")
+		if visibilitySM, err := ParseVisibilitySM(value); err == nil {
+			visibilities = append(visibilities, SecondaryVisibilityDec{
+				Direction:    fields[i+1],
+				Visibility:   value,
+				VisibilitySM: visibilitySM,
+			})
+		}
+	}
+	return visibilities
+}
@@ -0,0 +1,94 @@
+package avwx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVisibilityModifier(t *testing.T) {
+	tests := []struct {
+		raw          string
+		modifier     string
+		visibilitySM float64
+	}{
+		{"KSFO 221756Z 28010KT P6SM FEW008 18/12 A3002", VisibilityGreaterThan, 6},
+		{"KSFO 221756Z 28010KT M1/4SM FG VV001 18/12 A3002", VisibilityLessThan, 0.25},
+		{"KSFO 221756Z 28010KT 3SM BR 18/12 A3002", VisibilityExact, 3},
+		{"KSFO 221756Z 28010KT 18/12 A3002", "", 0},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.VisibilityModifier != tt.modifier || math.Abs(m.VisibilitySM-tt.visibilitySM) > 0.001 {
+			t.Errorf("%q: visibility = %q %v, want %q %v", tt.raw, m.VisibilityModifier, m.VisibilitySM, tt.modifier, tt.visibilitySM)
+		}
+		if len(m.DecodeErrors) > 0 {
+			t.Errorf("%q: decode errors %v", tt.raw, m.DecodeErrors)
+		}
+	}
+}
+
+func TestParseVisibilitySM(t *testing.T) {
+	tests := []struct {
+		token string
+		want  float64
+	}{
+		{"10SM", 10},
+		{"3/4SM", 0.75},
+		{"1 1/2SM", 1.5},
+		{"2 1/4", 2.25},
+		{"P6SM", 6},
+		{"M1/4SM", 0.25},
+	}
+	for _, tt := range tests {
+		got, err := ParseVisibilitySM(tt.token)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVisibilitySM(%q) = %v, %v, want %v", tt.token, got, err, tt.want)
+		}
+	}
+
+	for _, token := range []string{"", "SM", "1/0SM", "ABC", "1/2/3SM"} {
+		if got, err := ParseVisibilitySM(token); err == nil {
+			t.Errorf("ParseVisibilitySM(%q) = %v, want error", token, got)
+		}
+	}
+}
+
+func TestSecondaryVisibility(t *testing.T) {
+	tests := []struct {
+		raw  string
+		sm   float64
+		want []SecondaryVisibilityDec
+	}{
+		{"EGLL 221750Z 24015KT 4000 1500NE BR OVC004 12/11 Q1013", 4000 / metersPerStatuteMile,
+			[]SecondaryVisibilityDec{{Direction: "NE", Visibility: "1500", VisibilitySM: 1500 / metersPerStatuteMile}}},
+		{"EGLL 221750Z 24015KT 4000 1500 BR OVC004 12/11 Q1013", 4000 / metersPerStatuteMile,
+			[]SecondaryVisibilityDec{{Visibility: "1500", VisibilitySM: 1500 / metersPerStatuteMile}}},
+		{"KDEN 221753Z 17012KT 1 1/2SM BR OVC008 M01/M02 A2985 RMK VIS SW 3/4", 1.5,
+			[]SecondaryVisibilityDec{{Direction: "SW", Visibility: "3/4", VisibilitySM: 0.75}}},
+		{"KDEN 221753Z 17012KT 3SM BR OVC008 M01/M02 A2985 RMK VIS N 1 1/4", 3,
+			[]SecondaryVisibilityDec{{Direction: "N", Visibility: "1 1/4", VisibilitySM: 1.25}}},
+		{"KSFO 221756Z 28010KT 10SM FEW008 18/12 A3002", 10, nil},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if math.Abs(m.VisibilitySM-tt.sm) > 1e-9 {
+			t.Errorf("%q: VisibilitySM = %v, want %v", tt.raw, m.VisibilitySM, tt.sm)
+		}
+		if len(m.SecondaryVisibility) != len(tt.want) {
+			t.Errorf("%q: SecondaryVisibility = %+v, want %+v", tt.raw, m.SecondaryVisibility, tt.want)
+			continue
+		}
+		for i, want := range tt.want {
+			got := m.SecondaryVisibility[i]
+			if got.Direction != want.Direction || got.Visibility != want.Visibility || math.Abs(got.VisibilitySM-want.VisibilitySM) > 1e-9 {
+				t.Errorf("%q: SecondaryVisibility[%d] = %+v, want %+v", tt.raw, i, got, want)
+			}
+		}
+	}
+}
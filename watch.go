@@ -0,0 +1,88 @@
+package avwx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WatchPredicate decides whether a newly fetched report should be emitted by a watcher.
+// previous is the last report emitted, or nil before the first.
+type WatchPredicate func(previous, current *Metar) bool
+
+// NewObservation reports whether current is a different observation from previous.
+func NewObservation(previous, current *Metar) bool {
+	return previous == nil || previous.RawReport != current.RawReport
+}
+
+// FlightRulesChanged reports whether the flight rules differ from the previous report,
+// for example when a station drops below VFR.
+func FlightRulesChanged(previous, current *Metar) bool {
+	return previous == nil || !strings.EqualFold(previous.FlightRules, current.FlightRules)
+}
+
+// Watch polls the station at the given interval using DefaultClient, emitting each new observation.
+func Watch(ctx context.Context, station string, interval time.Duration) <-chan *MetarResponse {
+	return DefaultClient.Watch(ctx, station, interval)
+}
+
+// Watch polls the station at the given interval, emitting each new observation.
+// See WatchFunc.
+func (c *Client) Watch(ctx context.Context, station string, interval time.Duration) <-chan *MetarResponse {
+	return c.WatchFunc(ctx, station, interval, NewObservation)
+}
+
+// WatchFlightRules polls the station at the given interval, emitting a report only when its flight rules change.
+// See WatchFunc.
+func (c *Client) WatchFlightRules(ctx context.Context, station string, interval time.Duration) <-chan *MetarResponse {
+	return c.WatchFunc(ctx, station, interval, FlightRulesChanged)
+}
+
+// WatchFunc polls the station immediately and then at the given interval, emitting the reports
+// accepted by emit on the returned channel. Failed fetches are always emitted so callers can alert on them.
+// Polling stops and the channel is closed when ctx is cancelled. An interval that is not positive
+// emits a single error without polling.
+func (c *Client) WatchFunc(ctx context.Context, station string, interval time.Duration, emit WatchPredicate) <-chan *MetarResponse {
+	out := make(chan *MetarResponse)
+	go func() {
+		defer close(out)
+
+		if interval <= 0 {
+			metarResp := &MetarResponse{ICAO: station, Error: fmt.Errorf("Invalid watch interval: %s", interval)}
+			select {
+			case out <- metarResp:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var previous *Metar
+		for {
+			metarResp := c.FetchMetarContext(ctx, station)
+			if ctx.Err() != nil {
+				return
+			}
+			if metarResp.Error != nil || emit(previous, &metarResp.Metar) {
+				select {
+				case out <- metarResp:
+				case <-ctx.Done():
+					return
+				}
+				if metarResp.Error == nil {
+					previous = &metarResp.Metar
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,107 @@
+package avwx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// watchServer serves each report in turn with its flight rules, repeating the last one once they run out.
+func watchServer(reports []string) *httptest.Server {
+	var mu sync.Mutex
+	next := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		report := reports[next]
+		if next < len(reports)-1 {
+			next++
+		}
+		mu.Unlock()
+		metar, err := ParseMetar(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Raw-Report": %q, "Station": "KSFO", "Flight-Rules": %q}`, report, metar.FlightRules)
+	}))
+}
+
+func TestWatch(t *testing.T) {
+	reports := []string{
+		"KSFO 221656Z 28010KT 10SM FEW008 18/12 A3002",
+		"KSFO 221656Z 28010KT 10SM FEW008 18/12 A3002",
+		"KSFO 221756Z 28010KT 10SM SCT008 18/12 A3002",
+		"KSFO 221856Z 28010KT 2SM BR OVC008 16/14 A3000",
+		"KSFO 221956Z 28010KT 2SM BR OVC006 16/14 A3000",
+		"KSFO 222056Z 28010KT 10SM FEW008 18/12 A3002",
+	}
+	tests := []struct {
+		name  string
+		watch func(c *Client, ctx context.Context) <-chan *MetarResponse
+		want  []int
+	}{
+		{"Watch", func(c *Client, ctx context.Context) <-chan *MetarResponse {
+			return c.Watch(ctx, "KSFO", time.Millisecond)
+		}, []int{0, 2, 3, 4, 5}},
+		{"WatchFlightRules", func(c *Client, ctx context.Context) <-chan *MetarResponse {
+			return c.WatchFlightRules(ctx, "KSFO", time.Millisecond)
+		}, []int{0, 3, 5}},
+	}
+	for _, tt := range tests {
+		srv := watchServer(reports)
+		ctx, cancel := context.WithCancel(context.Background())
+		out := tt.watch(NewClient(WithBaseURL(srv.URL+"/")), ctx)
+
+		for _, i := range tt.want {
+			select {
+			case metarResp := <-out:
+				if metarResp.Error != nil {
+					t.Fatalf("%s: error %v", tt.name, metarResp.Error)
+				}
+				if metarResp.Metar.RawReport != reports[i] {
+					t.Errorf("%s: emitted %q, want %q", tt.name, metarResp.Metar.RawReport, reports[i])
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timed out waiting for %q", tt.name, reports[i])
+			}
+		}
+
+		cancel()
+		select {
+		case metarResp, ok := <-out:
+			if ok {
+				t.Errorf("%s: emitted %q after the last change", tt.name, metarResp.Metar.RawReport)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("%s: channel not closed after cancel", tt.name)
+		}
+		srv.Close()
+	}
+}
+
+func TestWatchInvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		out := NewClient(WithBaseURL("http://127.0.0.1:0/")).Watch(context.Background(), "KSFO", interval)
+		select {
+		case metarResp := <-out:
+			if metarResp == nil || metarResp.Error == nil {
+				t.Errorf("Watch(%v) emitted %+v, want an error", interval, metarResp)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Watch(%v) timed out", interval)
+		}
+		select {
+		case metarResp, ok := <-out:
+			if ok {
+				t.Errorf("Watch(%v) emitted %+v after the error", interval, metarResp)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("Watch(%v) channel not closed", interval)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package avwx
+
+import "strings"
+
+var precipitation = map[string]bool{
+	"DZ": true,
+	"RA": true,
+	"SN": true,
+	"SG": true,
+	"IC": true,
+	"PL": true,
+	"GR": true,
+	"GS": true,
+	"UP": true,
+}
+
+var hazards = map[string]bool{
+	"TS": true,
+	"FC": true,
+	"GR": true,
+	"VA": true,
+	"SQ": true,
+	"SS": true,
+	"DS": true,
+	"PO": true,
+}
+
+// HasThunderstorm reports whether a thunderstorm is present in the conditions or lightning is noted in the remarks.
+func (m *Metar) HasThunderstorm() bool {
+	if m.hasConditionCode(func(code string) bool { return code == "TS" }) {
+		return true
+	}
+	for _, remark := range strings.Fields(m.Remarks) {
+		if strings.HasPrefix(remark, "LTG") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPrecipitation reports whether any form of precipitation is present in the conditions.
+func (m *Metar) HasPrecipitation() bool {
+	return m.hasConditionCode(func(code string) bool { return precipitation[code] })
+}
+
+// HasHazardousWeather reports whether any hazardous phenomenon is present: thunderstorms, funnel clouds,
+// hail, volcanic ash, squalls, sand or dust storms, freezing precipitation or any heavy precipitation.
+func (m *Metar) HasHazardousWeather() bool {
+	if m.HasThunderstorm() || m.HasFreezingPrecip() {
+		return true
+	}
+	for _, condition := range m.Conditions {
+		if strings.HasPrefix(condition, "+") {
+			return true
+		}
+	}
+	return m.hasConditionCode(func(code string) bool { return hazards[code] })
+}
+
+func (m *Metar) hasConditionCode(match func(code string) bool) bool {
+	for _, condition := range m.Conditions {
+		for _, code := range conditionCodes(condition) {
+			if match(code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// conditionCodes splits a weather token such as "+TSRA" into its two letter codes.
+func conditionCodes(condition string) []string {
+	condition = strings.TrimLeft(condition, "-+")
+	codes := make([]string, 0, len(condition)/2)
+	for len(condition) >= 2 {
+		codes = append(codes, condition[:2])
+		condition = condition[2:]
+	}
+	return codes
+}
+
+// HasFreezingPrecip reports whether any freezing phenomenon such as FZRA, FZDZ or FZFG is present.
+func (m *Metar) HasFreezingPrecip() bool {
+	for _, condition := range m.Conditions {
+		if strings.HasPrefix(strings.TrimLeft(condition, "-+"), "FZ") {
+			return true
+		}
+	}
+	return false
+}
+
+// WeatherDescriptions returns each decoded weather condition as plain English, such as "LIGHT RAIN"
+// or "SHOWERS IN VICINITY". It returns an empty slice when no weather is reported.
+func (m *Metar) WeatherDescriptions() []string {
+	descriptions := make([]string, 0, len(m.ConditionsDec))
+	for _, condition := range m.ConditionsDec {
+		var words []string
+		for _, word := range []string{condition.Modifier, condition.Descriptor, condition.Desc, condition.Other} {
+			if word != "" {
+				words = append(words, word)
+			}
+		}
+		if len(words) > 0 {
+			descriptions = append(descriptions, strings.Join(words, " "))
+		}
+	}
+	return descriptions
+}
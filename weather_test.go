@@ -0,0 +1,48 @@
+package avwx
+
+import "testing"
+
+func TestHazardousWeather(t *testing.T) {
+	tests := []struct {
+		raw          string
+		thunderstorm bool
+		hazardous    bool
+		precip       bool
+	}{
+		{"KSFO 221756Z 28010KT 5SM TSRA BKN030CB 18/12 A3002", true, true, true},
+		{"KOKC 221756Z 18020KT 5SM FC BKN030CB 25/20 A2980", false, true, false},
+		{"KSFO 221756Z 28010KT 8SM -RA BKN030 18/12 A3002", false, false, true},
+		{"KSFO 221756Z 28010KT 8SM -RA BKN030 18/12 A3002 RMK LTG DSNT W", true, true, true},
+		{"KSFO 221756Z 28010KT 2SM +RA BKN030 18/12 A3002", false, true, true},
+		{"KBOS 221756Z 05010KT 2SM FZRA OVC010 M01/M02 A3002", false, true, true},
+		{"KSFO 221756Z 28010KT 10SM FEW030 18/12 A3002", false, false, false},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		if m.HasThunderstorm() != tt.thunderstorm || m.HasHazardousWeather() != tt.hazardous || m.HasPrecipitation() != tt.precip {
+			t.Errorf("%q: HasThunderstorm, HasHazardousWeather, HasPrecipitation = %v, %v, %v, want %v, %v, %v", tt.raw,
+				m.HasThunderstorm(), m.HasHazardousWeather(), m.HasPrecipitation(), tt.thunderstorm, tt.hazardous, tt.precip)
+		}
+	}
+}
+
+func TestWeatherDescriptions(t *testing.T) {
+	tests := []struct {
+		conditions []string
+		want       []string
+	}{
+		{[]string{"-RA", "BR"}, []string{"LIGHT RAIN", "MIST"}},
+		{[]string{"+TSRA", "VCSH"}, []string{"HEAVY THUNDERSTORM RAIN", "SHOWERS IN VICINITY"}},
+		{[]string{"-SNRA", "FZFG", "BLSN"}, []string{"LIGHT SNOW/RAIN", "FREEZING FOG", "BLOWING SNOW"}},
+		{nil, []string{}},
+	}
+	for _, tt := range tests {
+		got := decode(Metar{Conditions: tt.conditions}).WeatherDescriptions()
+		if got == nil || !equalStrings(got, tt.want) {
+			t.Errorf("WeatherDescriptions() of %q = %q, want %q", tt.conditions, got, tt.want)
+		}
+	}
+}
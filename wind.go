@@ -0,0 +1,105 @@
+package avwx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GustThreshold is the gust factor in knots above which winds are considered gusty.
+const GustThreshold = 10
+
+// ErrVariableWind is returned when a calculation needs a wind direction but the wind is variable.
+var ErrVariableWind = errors.New("Wind direction is variable")
+
+// GustFactor returns the difference in knots between the gust and steady wind speed.
+// ok is false when no gust is reported.
+func (m *Metar) GustFactor() (factor int, ok bool) {
+	gust, err := strconv.Atoi(m.WindGust)
+	if err != nil {
+		return 0, false
+	}
+	speed, err := strconv.Atoi(m.WindSpeed)
+	if err != nil {
+		return 0, false
+	}
+	return gust - speed, true
+}
+
+// IsGusty reports whether the gust factor exceeds GustThreshold.
+func (m *Metar) IsGusty() bool {
+	factor, ok := m.GustFactor()
+	return ok && factor > GustThreshold
+}
+
+var windArrows = [8]string{"↑", "↗", "→", "↘", "↓", "↙", "←", "↖"}
+
+// WindArrow returns an arrow pointing in the direction the wind is blowing toward, the opposite of the
+// reported direction, so a north wind gives "↓". Calm and variable winds give "·" and a missing
+// direction gives "".
+func (m *Metar) WindArrow() string {
+	switch m.WindDirectionDesc {
+	case "CALM", "VARIABLE":
+		return "·"
+	case "":
+		return ""
+	}
+	toward := (m.WindDirectionDeg + 180) % 360
+	return windArrows[(toward+22)/45%8]
+}
+
+// IsCalm reports whether the wind is calm, reported as 00000KT.
+// A calm wind has no meaningful direction.
+func (m *Metar) IsCalm() bool {
+	speed, err := strconv.Atoi(m.WindSpeed)
+	return err == nil && speed == 0
+}
+
+// WindComponents splits the steady wind into components relative to a runway heading in degrees.
+// A positive headwind blows down the runway toward the aircraft, a negative one is a tailwind.
+// A positive crosswind comes from the right of the runway heading, a negative one from the left.
+func (m *Metar) WindComponents(runwayHeading int) (headwind, crosswind float64, err error) {
+	if m.WindDirection == "VRB" {
+		return 0, 0, ErrVariableWind
+	}
+	direction, err := strconv.Atoi(m.WindDirection)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid wind direction: %s", m.WindDirection)
+	}
+	speed, err := strconv.Atoi(m.WindSpeed)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid wind speed: %s", m.WindSpeed)
+	}
+
+	angle := float64(direction-runwayHeading) * math.Pi / 180
+	headwind = float64(speed) * math.Cos(angle)
+	crosswind = float64(speed) * math.Sin(angle)
+	return headwind, crosswind, nil
+}
+
+// decodeWindShear returns the runways named by wind shear groups such as "WS RWY18" or "WS R27L",
+// with "ALL" for "WS ALL RWY".
+func decodeWindShear(groups []string) []string {
+	var runways []string
+	for i := 0; i+1 < len(groups); i++ {
+		if groups[i] != "WS" {
+			continue
+		}
+		switch next := groups[i+1]; {
+		case next == "ALL":
+			runways = append(runways, "ALL")
+		case strings.HasPrefix(next, "RWY"):
+			runways = append(runways, next[3:])
+		case strings.HasPrefix(next, "R") && len(next) > 1:
+			runways = append(runways, next[1:])
+		}
+	}
+	return runways
+}
+
+// HasWindShear reports whether low level wind shear is reported for any runway.
+func (m *Metar) HasWindShear() bool {
+	return len(m.WindShear) > 0
+}
@@ -0,0 +1,29 @@
+package avwx
+
+import "testing"
+
+func TestGustFactor(t *testing.T) {
+	tests := []struct {
+		raw    string
+		factor int
+		ok     bool
+		gusty  bool
+	}{
+		{"KSFO 221756Z 28012G28KT 10SM 18/12 A3002", 16, true, true},
+		{"KSFO 221756Z 28012G20KT 10SM 18/12 A3002", 8, true, false},
+		{"KSFO 221756Z 28012KT 10SM 18/12 A3002", 0, false, false},
+	}
+	for _, tt := range tests {
+		m, err := ParseMetar(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseMetar(%q) error: %v", tt.raw, err)
+		}
+		factor, ok := m.GustFactor()
+		if factor != tt.factor || ok != tt.ok {
+			t.Errorf("%q: GustFactor() = %d, %v, want %d, %v", tt.raw, factor, ok, tt.factor, tt.ok)
+		}
+		if m.IsGusty() != tt.gusty {
+			t.Errorf("%q: IsGusty() = %v, want %v", tt.raw, m.IsGusty(), tt.gusty)
+		}
+	}
+}